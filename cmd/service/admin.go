@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/cmd/service/modulebuilder"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/responses"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/metrics"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
+	"github.com/google/uuid"
+)
+
+// adminContext returns a context that attributes audited changes to the CLI operator.
+func adminContext() context.Context {
+	return audit.WithActor(context.Background(), audit.CLIActor)
+}
+
+// withUserAuthServices connects to the database and calls fn with the user-auth services.
+func withUserAuthServices(cfg config.Config, fn func(services modulebuilder.UserAuthServices) error) error {
+	gormDB, err := db.NewGormDB(context.Background(), cfg.DB)
+	if err != nil {
+		return fmt.Errorf("new db connection: %w", err)
+	}
+	defer func() {
+		dbConnection, _ := gormDB.DB()
+		dbConnection.Close()
+	}()
+
+	auditModule := modulebuilder.BuildAuditModule(gormDB)
+
+	cluster := db.NewCluster(time.Now, db.ClusterConfig{}, gormDB)
+
+	services, err := modulebuilder.BuildUserAuthServices(cfg, cluster, auditModule.Service, metrics.NewAuthMetrics(nil))
+	if err != nil {
+		return fmt.Errorf("build user-auth services: %w", err)
+	}
+	defer services.Close()
+
+	return fn(services)
+}
+
+// admin manages privileged users.
+func admin(cfg config.Config, args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return fmt.Errorf("admin expects create: %w", flag.ErrHelp)
+	}
+
+	flags := flag.NewFlagSet("admin create", flag.ContinueOnError)
+	email := flags.String("email", "", "email address (required)")
+	name := flags.String("name", "", "full name")
+	passwordFlag := flags.String("password", "", "password, generated and printed if empty")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if *email == "" {
+		return fmt.Errorf("--email is required: %w", flag.ErrHelp)
+	}
+
+	password, generated, err := passwordOrGenerate(*passwordFlag)
+	if err != nil {
+		return err
+	}
+
+	return withUserAuthServices(cfg, func(services modulebuilder.UserAuthServices) error {
+		user, err := services.UserService.CreateUser(adminContext(), *email, *name, password, models.RoleAdmin)
+		if err != nil {
+			return fmt.Errorf("create admin: %w", err)
+		}
+
+		fmt.Printf("Created admin %s (%s)\n", user.Email, user.ID)
+		printGeneratedPassword(password, generated)
+
+		return nil
+	})
+}
+
+// userCommand changes existing users.
+func userCommand(cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("user expects reset-password, ban or unban: %w", flag.ErrHelp)
+	}
+
+	flags := flag.NewFlagSet("user "+args[0], flag.ContinueOnError)
+	id := flags.String("id", "", "user ID")
+	email := flags.String("email", "", "email address, if --id is not given")
+
+	var password *string
+	if args[0] == "reset-password" {
+		password = flags.String("password", "", "new password, generated and printed if empty")
+	}
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	return withUserAuthServices(cfg, func(services modulebuilder.UserAuthServices) error {
+		ctx := adminContext()
+
+		user, err := findUser(ctx, services, *id, *email)
+		if err != nil {
+			return err
+		}
+
+		switch args[0] {
+		case "reset-password":
+			newPassword, generated, err := passwordOrGenerate(*password)
+			if err != nil {
+				return err
+			}
+
+			if err := services.UserService.ResetPassword(ctx, user.ID, newPassword); err != nil {
+				return fmt.Errorf("reset password: %w", err)
+			}
+
+			fmt.Printf("Password of %s reset\n", user.Email)
+			printGeneratedPassword(newPassword, generated)
+		case "ban":
+			if err := services.UserService.Ban(ctx, user.ID); err != nil {
+				return fmt.Errorf("ban user: %w", err)
+			}
+
+			fmt.Printf("Banned %s\n", user.Email)
+		case "unban":
+			if err := services.UserService.Unban(ctx, user.ID); err != nil {
+				return fmt.Errorf("unban user: %w", err)
+			}
+
+			fmt.Printf("Unbanned %s\n", user.Email)
+		default:
+			return fmt.Errorf("unknown user command %q: %w", args[0], flag.ErrHelp)
+		}
+
+		return nil
+	})
+}
+
+// tokenCommand mints tokens for a user. It must be enabled with AUTH_ALLOW_TOKEN_MINT and is always
+// refused in production.
+func tokenCommand(cfg config.Config, args []string) error {
+	if len(args) == 0 || args[0] != "mint" {
+		return fmt.Errorf("token expects mint: %w", flag.ErrHelp)
+	}
+
+	if cfg.App.IsProduction() {
+		return errors.New("token mint is not available in production")
+	}
+
+	if !cfg.Auth.AllowTokenMint {
+		return errors.New("token mint is disabled, set AUTH_ALLOW_TOKEN_MINT=true to enable it")
+	}
+
+	flags := flag.NewFlagSet("token mint", flag.ContinueOnError)
+	id := flags.String("id", "", "user ID")
+	email := flags.String("email", "", "email address, if --id is not given")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	return withUserAuthServices(cfg, func(services modulebuilder.UserAuthServices) error {
+		ctx := adminContext()
+
+		user, err := findUser(ctx, services, *id, *email)
+		if err != nil {
+			return err
+		}
+
+		// Each login starts a session, which refreshes continue
+		ctx = token.WithSession(ctx, token.NewSessionID())
+
+		accessToken, exp, err := services.TokenService.CreateAccessToken(ctx, &user)
+		if err != nil {
+			return fmt.Errorf("create access token: %w", err)
+		}
+
+		refreshToken, err := services.TokenService.CreateRefreshToken(ctx, &user)
+		if err != nil {
+			return fmt.Errorf("create refresh token: %w", err)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(responses.NewLoginResponse(accessToken, refreshToken, exp))
+	})
+}
+
+func findUser(ctx context.Context, services modulebuilder.UserAuthServices, id, email string) (models.User, error) {
+	switch {
+	case id != "":
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			return models.User{}, fmt.Errorf("parse --id: %w", err)
+		}
+
+		return services.UserService.GetByID(ctx, userID)
+	case email != "":
+		return services.UserService.GetUserByEmail(ctx, email)
+	default:
+		return models.User{}, fmt.Errorf("--id or --email is required: %w", flag.ErrHelp)
+	}
+}
+
+// passwordOrGenerate returns password, or a random password if it is empty.
+func passwordOrGenerate(password string) (_ string, generated bool, _ error) {
+	if password != "" {
+		return password, false, nil
+	}
+
+	random := make([]byte, 18)
+	if _, err := rand.Read(random); err != nil {
+		return "", false, fmt.Errorf("generate password: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(random), true, nil
+}
+
+func printGeneratedPassword(password string, generated bool) {
+	if generated {
+		fmt.Printf("Generated password: %s\nIt is not shown again.\n", password)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/password/policy"
+)
+
+// breachedCommand manages the breached password corpus read from PASSWORD_BREACHED_CORPUS_FILE.
+func breachedCommand(args []string) error {
+	if len(args) == 0 || args[0] != "build" {
+		return fmt.Errorf("breached expects build: %w", flag.ErrHelp)
+	}
+
+	flags := flag.NewFlagSet("breached build", flag.ContinueOnError)
+	source := flags.String("source", "", "passwords or SHA-1 digests, one per line (required)")
+	out := flags.String("out", "", "corpus file to write (required)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if *source == "" || *out == "" {
+		return fmt.Errorf("--source and --out are required: %w", flag.ErrHelp)
+	}
+
+	count, err := buildBreachedCorpus(*source, *out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %d breached password digests to %s\n", count, *out)
+
+	return nil
+}
+
+// buildBreachedCorpus writes the corpus next to out and renames it into place once complete, so a
+// running server never opens a partial file.
+func buildBreachedCorpus(sourcePath, outPath string) (int, error) {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return 0, fmt.Errorf("open corpus source: %w", err)
+	}
+	defer source.Close()
+
+	out, err := os.CreateTemp(filepath.Dir(outPath), filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("create corpus file: %w", err)
+	}
+	defer os.Remove(out.Name())
+
+	count, err := policy.BuildBreachedCorpus(source, out)
+	if err != nil {
+		return 0, errors.Join(err, out.Close())
+	}
+
+	if err := out.Close(); err != nil {
+		return 0, fmt.Errorf("close corpus file: %w", err)
+	}
+
+	if err := os.Rename(out.Name(), outPath); err != nil {
+		return 0, fmt.Errorf("rename corpus file: %w", err)
+	}
+
+	return count, nil
+}
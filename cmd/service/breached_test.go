@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/password/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildBreachedCorpus(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	sourcePath := filepath.Join(dir, "passwords.txt")
+	outPath := filepath.Join(dir, "breached.bin")
+	require.NoError(t, os.WriteFile(sourcePath, []byte("hunter2\ntrustno1\nhunter2\n"), 0o600))
+
+	count, err := buildBreachedCorpus(sourcePath, outPath)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Only the corpus is left, the temporary file was renamed
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	corpus, err := policy.OpenBreachedCorpus(outPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { corpus.Close() })
+
+	breached, err := corpus.Contains("trustno1")
+	require.NoError(t, err)
+	assert.True(t, breached)
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+)
+
+// configCommand inspects the effective configuration.
+func configCommand(cfg config.Config, args []string) error {
+	if len(args) != 1 || args[0] != "print" {
+		return fmt.Errorf("config expects print: %w", flag.ErrHelp)
+	}
+
+	return config.Print(os.Stdout, cfg)
+}
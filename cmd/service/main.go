@@ -1,32 +1,38 @@
 package main
 
 import (
-	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
-	"os/signal"
-	"syscall"
-	"time"
 
-	"github.com/game-platform-ai/golang-echo-boilerplate/cmd/service/modulebuilder"
 	_ "github.com/game-platform-ai/golang-echo-boilerplate/docs"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
-	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db"
-	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
-	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server"
-	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/routes"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
 
-	"github.com/caarlos0/env/v11"
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
 	"github.com/joho/godotenv"
-	echojwt "github.com/labstack/echo-jwt/v4"
-	"github.com/labstack/echo/v4"
 )
 
-const shutdownTimeout = 20 * time.Second
+const usage = `Usage: service [--config FILE] [--set KEY=VALUE]... <command> [arguments]
+
+Options:
+  --config FILE                                 YAML or TOML config file, also CONFIG_FILE
+  --set KEY=VALUE                               override a config value, repeatable
+
+Commands:
+  serve [--migrate-on-start]                    run the HTTP server (default)
+  migrate up|down|status|redo                   manage the database schema
+  admin create --email E [--name N] [--password P]
+                                                create an admin user
+  user reset-password (--id ID | --email E) [--password P]
+                                                set a new password, generated if omitted
+  user ban|unban (--id ID | --email E)          ban or unban a user
+  token mint (--id ID | --email E)              print tokens for a user, if AUTH_ALLOW_TOKEN_MINT
+  seed --file F                                 load users from a YAML file
+  breached build --source F --out F             build the breached password corpus from a list
+  config print                                  print the effective config, secrets redacted
+`
 
 //	@title			User Auth API
 //	@version		1.0
@@ -42,84 +48,86 @@ const shutdownTimeout = 20 * time.Second
 
 // @BasePath	/api/external/v1
 func main() {
-	if err := run(); err != nil {
+	if err := run(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			if err != flag.ErrHelp {
+				fmt.Fprintln(os.Stderr, err)
+			}
+
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+
 		slog.Error("Service run error", "err", err.Error())
 		os.Exit(1)
 	}
 }
 
-func run() error {
+func run(args []string) error {
 	// Load env file
 	if err := godotenv.Load(); err != nil {
 		slog.Warn("Could not load .env file, using environment variables", "err", err.Error())
 	}
 
-	// Parse config from env
-	var cfg config.Config
-	if err := env.Parse(&cfg); err != nil {
-		return fmt.Errorf("parse env: %w", err)
-	}
+	// Parse global flags
+	options := config.LoadOptions{File: os.Getenv("CONFIG_FILE")}
 
-	// Init logger
-	if err := slogx.Init(cfg.Logger); err != nil {
-		return fmt.Errorf("init logger: %w", err)
-	}
+	flags := flag.NewFlagSet("service", flag.ContinueOnError)
+	flags.StringVar(&options.File, "config", options.File, "YAML or TOML config file")
+	flags.Func("set", "override a config value as KEY=VALUE, repeatable", func(value string) error {
+		options.Overrides = append(options.Overrides, value)
 
-	// Init DB connection
-	traceStarter := slogx.NewTraceStarter(uuid.NewV7)
+		return nil
+	})
 
-	// Init DB connection
-	gormDB, err := db.NewGormDB(cfg.DB)
-	if err != nil {
-		return fmt.Errorf("new db connection: %w", err)
+	if err := flags.Parse(args); err != nil {
+		return err
 	}
-	defer func() {
-		dbConnection, _ := gormDB.DB()
-		dbConnection.Close()
-	}()
 
-	userAuthHandlers, err := modulebuilder.BuildUserAuthModule(cfg, gormDB)
+	args = flags.Args()
+
+	// Load config from defaults, file, env and overrides
+	cfg, err := config.Load(os.Environ(), options)
 	if err != nil {
-		return fmt.Errorf("build user-auth module: %w", err)
+		return fmt.Errorf("load config: %w", err)
 	}
 
-	// Configure middleware with the custom claims type
-	echoJWTConfig := echojwt.Config{
-		NewClaimsFunc: func(echo.Context) jwt.Claims {
-			return new(token.JwtCustomClaims)
-		},
-		SigningKey: []byte(cfg.Auth.AccessSecret),
+	// Init logger
+	logging, err := slogx.Init(cfg.Logger)
+	if err != nil {
+		return fmt.Errorf("init logger: %w", err)
 	}
+	defer logging.Close()
 
-	allHandlers := routes.Handlers{
-		AuthHandler:       userAuthHandlers.AuthHandler,
-		OAuthHandler:      userAuthHandlers.OAuthHandler,
-		RegisterHandler:   userAuthHandlers.RegisterHandler,
-		EchoJWTMiddleware: echojwt.WithConfig(echoJWTConfig),
-	}
+	// Reopen log files when logrotate signals that it moved them
+	stopReopen := reopenOnSignal(logging)
+	defer stopReopen()
 
-	engine := echo.New()
-	if err := routes.ConfigureRoutes(traceStarter, engine, allHandlers); err != nil {
-		return fmt.Errorf("configure routes: %w", err)
+	command := "serve"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
 	}
 
-	app := server.NewServer(engine)
-	go func() {
-		if err = app.Start(cfg.HTTP.Port); err != nil {
-			slog.Error("Server error", "err", err.Error())
-		}
-	}()
-
-	shutdownChannel := make(chan os.Signal, 1)
-	signal.Notify(shutdownChannel, os.Interrupt, syscall.SIGHUP, syscall.SIGTERM)
-	<-shutdownChannel
-
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
-	defer cancel()
-
-	if err := app.Shutdown(shutdownCtx); err != nil {
-		return fmt.Errorf("http server shutdown: %w", err)
+	switch command {
+	case "serve":
+		return serve(cfg, logging, args)
+	case "migrate":
+		return migrate(cfg, args)
+	case "admin":
+		return admin(cfg, args)
+	case "user":
+		return userCommand(cfg, args)
+	case "token":
+		return tokenCommand(cfg, args)
+	case "seed":
+		return seed(cfg, args)
+	case "breached":
+		return breachedCommand(args)
+	case "config":
+		return configCommand(cfg, args)
+	case "help", "-h", "--help":
+		return flag.ErrHelp
+	default:
+		return fmt.Errorf("unknown command %q: %w", command, flag.ErrHelp)
 	}
-
-	return nil
 }
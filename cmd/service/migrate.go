@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db"
+)
+
+// migrate applies or inspects the embedded database migrations.
+func migrate(cfg config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("migrate expects one of up, down, status, redo: %w", flag.ErrHelp)
+	}
+
+	gormDB, err := db.NewGormDB(context.Background(), cfg.DB)
+	if err != nil {
+		return fmt.Errorf("new db connection: %w", err)
+	}
+
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		return fmt.Errorf("get sql db: %w", err)
+	}
+	defer sqlDB.Close()
+
+	migrator, err := db.NewMigrator(sqlDB)
+	if err != nil {
+		return fmt.Errorf("new migrator: %w", err)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		return migrator.Down(ctx)
+	case "redo":
+		return migrator.Redo(ctx)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tAPPLIED AT\tNAME")
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, appliedAt, status.Name)
+		}
+
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %q: %w", args[0], flag.ErrHelp)
+	}
+}
@@ -0,0 +1,27 @@
+package modulebuilder
+
+import (
+	"time"
+
+	repositories "github.com/game-platform-ai/golang-echo-boilerplate/internal/repositories/audit"
+	handlers "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/handlers/audit"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
+	"gorm.io/gorm"
+)
+
+// auditModule chứa service và handler của audit log.
+type auditModule struct {
+	Service      *audit.Service
+	AuditHandler *handlers.AuditHandler
+}
+
+// BuildAuditModule xây dựng module audit log.
+func BuildAuditModule(db *gorm.DB) auditModule {
+	entryRepository := repositories.NewEntryRepository(db)
+	auditService := audit.NewService(time.Now, entryRepository)
+
+	return auditModule{
+		Service:      auditService,
+		AuditHandler: handlers.NewAuditHandler(auditService),
+	}
+}
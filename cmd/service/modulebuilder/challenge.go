@@ -0,0 +1,70 @@
+package modulebuilder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/tracing"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/challenge"
+	handlers "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/handlers/challenge"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+// challengeModule chứa handler cấp challenge và middleware bảo vệ các route dễ bị bot lạm dụng.
+type challengeModule struct {
+	ChallengeHandler *handlers.ChallengeHandler
+	Guard            echo.MiddlewareFunc
+}
+
+// BuildChallengeModule xây dựng module chống bot. Khi bị tắt, Guard cho mọi request đi qua.
+func BuildChallengeModule(cfg config.ChallengeConfig) (challengeModule, error) {
+	if cfg.Secret == "" {
+		if cfg.Enabled {
+			return challengeModule{}, errors.New("CHALLENGE_SECRET is required")
+		}
+
+		// Challenges are still issued so that clients work the same way in every environment
+		cfg.Secret = "disabled"
+	}
+
+	proofOfWork, err := challenge.NewProofOfWork(time.Now, []byte(cfg.Secret), cfg.Difficulty, cfg.TTL)
+	if err != nil {
+		return challengeModule{}, fmt.Errorf("new proof of work: %w", err)
+	}
+
+	module := challengeModule{ChallengeHandler: handlers.NewChallengeHandler(proofOfWork)}
+
+	if !cfg.Enabled {
+		module.Guard = func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+
+		return module, nil
+	}
+
+	allowlist, err := challenge.NewAllowlist(cfg.AllowCIDRs, cfg.AllowClientKeys)
+	if err != nil {
+		return challengeModule{}, fmt.Errorf("new allowlist: %w", err)
+	}
+
+	guardConfig := middleware.ChallengeGuardConfig{
+		ProofOfWork: proofOfWork,
+		Allowlist:   allowlist,
+	}
+
+	switch cfg.CaptchaProvider {
+	case "":
+	case "siteverify":
+		guardConfig.CaptchaVerifier = challenge.NewSiteVerify(tracing.NewClient(5*time.Second), cfg.CaptchaVerifyURL, cfg.CaptchaSecret)
+	case "static":
+		guardConfig.CaptchaVerifier = challenge.NewStaticCaptcha(cfg.CaptchaStaticToken)
+	default:
+		return challengeModule{}, fmt.Errorf("unknown captcha provider %q", cfg.CaptchaProvider)
+	}
+
+	module.Guard = middleware.NewChallengeGuard(guardConfig)
+
+	return module, nil
+}
@@ -0,0 +1,23 @@
+package modulebuilder
+
+import (
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	handlers "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/handlers/health"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/health"
+)
+
+// healthModule chứa service kiểm tra sức khỏe và handler cho các probe.
+type healthModule struct {
+	Service       *health.Service
+	HealthHandler *handlers.HealthHandler
+}
+
+// BuildHealthModule xây dựng module health từ các check của những dependency.
+func BuildHealthModule(cfg config.HTTPConfig, checks ...health.Check) healthModule {
+	service := health.NewService(cfg.ReadinessTimeout, checks...)
+
+	return healthModule{
+		Service:       service,
+		HealthHandler: handlers.NewHealthHandler(service),
+	}
+}
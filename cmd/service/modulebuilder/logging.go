@@ -0,0 +1,41 @@
+package modulebuilder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	handlers "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/handlers/logging"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/logging"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+
+	"github.com/labstack/echo/v4"
+)
+
+// loggingModule chứa handler điều khiển log level lúc runtime và middleware nâng log level cho một request.
+type loggingModule struct {
+	LoggingHandler  *handlers.LoggingHandler
+	DebugEscalation echo.MiddlewareFunc
+}
+
+// BuildLoggingModule xây dựng module logging. Debug token chỉ được bật khi có LOG_DEBUG_SECRET.
+func BuildLoggingModule(cfg config.LogConfig, levels *slogx.Levels, auditService *audit.Service) (loggingModule, error) {
+	if cfg.DebugSecret == "" {
+		return loggingModule{
+			LoggingHandler:  handlers.NewLoggingHandler(logging.NewService(levels, nil, cfg.DebugTokenMaxTTL, auditService)),
+			DebugEscalation: middleware.NewDebugEscalation(nil),
+		}, nil
+	}
+
+	debugTokens, err := slogx.NewDebugTokens(time.Now, []byte(cfg.DebugSecret))
+	if err != nil {
+		return loggingModule{}, fmt.Errorf("new debug tokens: %w", err)
+	}
+
+	return loggingModule{
+		LoggingHandler:  handlers.NewLoggingHandler(logging.NewService(levels, debugTokens, cfg.DebugTokenMaxTTL, auditService)),
+		DebugEscalation: middleware.NewDebugEscalation(debugTokens),
+	}, nil
+}
@@ -0,0 +1,62 @@
+package modulebuilder
+
+import (
+	"errors"
+	"net/http"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/challenge"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/password/policy"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/phone"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/problem"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/logging"
+)
+
+// BuildProblemRegistry ánh xạ các lỗi nghiệp vụ sang HTTP status và mã lỗi ổn định cho client.
+// Mã lỗi là một phần của API: chỉ thêm mã mới, không đổi mã đã có.
+func BuildProblemRegistry() *problem.Registry {
+	registry := problem.NewRegistry()
+
+	register := func(target error, status int, code, title string) {
+		registry.Register(target, problem.Definition{Status: status, Code: code, Title: title})
+	}
+
+	// Authentication
+	register(models.ErrInvalidCredentials, http.StatusUnauthorized, "invalid_credentials", "Invalid credentials")
+	register(models.ErrInvalidAuthToken, http.StatusUnauthorized, "invalid_token", "Invalid or expired token")
+	register(models.ErrInvalidOneTimeCode, http.StatusUnauthorized, "invalid_code", "Invalid or expired code")
+	register(models.ErrUserBanned, http.StatusForbidden, "user_banned", "User is banned")
+	register(models.ErrTooManyRequests, http.StatusTooManyRequests, "too_many_requests", "Too many requests, try again later")
+	register(challenge.ErrChallengeRequired, http.StatusForbidden, "challenge_required", "Challenge required")
+	register(challenge.ErrChallengeFailed, http.StatusForbidden, "challenge_failed", "Challenge failed")
+
+	// Users
+	register(models.ErrUserNotFound, http.StatusNotFound, "user_not_found", "User not found")
+	register(models.ErrUserExists, http.StatusConflict, "user_exists", "User already exists")
+	register(models.ErrInvalidPassword, http.StatusBadRequest, "invalid_password", "Current password is incorrect")
+	register(models.ErrInvalidRole, http.StatusBadRequest, "invalid_role", "Invalid role")
+	register(models.ErrImpersonationForbidden, http.StatusForbidden, "impersonation_forbidden", "User cannot be impersonated")
+	register(phone.ErrInvalidNumber, http.StatusBadRequest, "invalid_phone_number", "Invalid phone number")
+	register(models.ErrPhoneTaken, http.StatusConflict, "phone_taken", "Phone number is already in use")
+
+	registry.Register(policy.ErrPolicyViolation, problem.Definition{
+		Status: http.StatusBadRequest,
+		Code:   "password_policy",
+		Title:  "Password does not meet the password policy",
+		Extensions: func(err error) map[string]any {
+			var violations *policy.ViolationsError
+			if !errors.As(err, &violations) {
+				return nil
+			}
+
+			return map[string]any{"violations": violations.Violations}
+		},
+	})
+
+	// Logging
+	register(logging.ErrInvalidLevel, http.StatusBadRequest, "invalid_log_level", "Invalid log level")
+	register(logging.ErrInvalidTTL, http.StatusBadRequest, "invalid_ttl", "Invalid ttl")
+	register(logging.ErrDebugTokensUnavailable, http.StatusNotImplemented, "debug_tokens_unavailable", "Debug tokens are not configured")
+
+	return registry
+}
@@ -2,59 +2,305 @@ package modulebuilder
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/metrics"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/tracing"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/mailer"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/password"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/password/policy"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/phone"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/sms"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
 	repositories "github.com/game-platform-ai/golang-echo-boilerplate/internal/repositories/user-auth"
 	handlers "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/handlers/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/health"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/emaillogin"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/impersonation"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/loginhistory"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/oauth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/otp"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/phonelogin"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/user"
-	"gorm.io/gorm"
 )
 
 // userAuthHandlers chứa các handler được tạo ra bởi module này.
 type userAuthHandlers struct {
-	AuthHandler     *handlers.AuthHandler
-	OAuthHandler    *handlers.OAuthHandler
-	RegisterHandler *handlers.RegisterHandler
+	AuthHandler          *handlers.AuthHandler
+	OAuthHandler         *handlers.OAuthHandler
+	RegisterHandler      *handlers.RegisterHandler
+	LoginHistoryHandler  *handlers.LoginHistoryHandler
+	UserAdminHandler     *handlers.UserAdminHandler
+	PasswordHandler      *handlers.PasswordHandler
+	EmailLoginHandler    *handlers.EmailLoginHandler
+	PhoneHandler         *handlers.PhoneHandler
+	ImpersonationHandler *handlers.ImpersonationHandler
+
+	// Checks of the external dependencies of the module, for readiness.
+	HealthChecks []health.Check
+
+	services UserAuthServices
 }
 
-// BuildUserAuthModule xây dựng module user-auth bao gồm repository, service và handler.
-func BuildUserAuthModule(cfg config.Config, db *gorm.DB) (userAuthHandlers, error) {
+// Close giải phóng tài nguyên của các service lõi.
+func (h userAuthHandlers) Close() error {
+	return h.services.Close()
+}
+
+// UserAuthServices chứa các service lõi dùng chung giữa HTTP server và CLI quản trị.
+type UserAuthServices struct {
+	PasswordHasher *password.Service
+	UserService    *user.Service
+	TokenService   *token.Service
+
+	breachedCorpus *policy.BreachedCorpus
+}
+
+// Close đóng file breached corpus nếu có.
+func (s UserAuthServices) Close() error {
+	if s.breachedCorpus == nil {
+		return nil
+	}
+
+	return s.breachedCorpus.Close()
+}
+
+// BuildUserAuthServices xây dựng các service lõi của user-auth. Hàm này không gọi tới dịch vụ bên ngoài.
+func BuildUserAuthServices(
+	cfg config.Config,
+	cluster *db.Cluster,
+	auditService *audit.Service,
+	authMetrics *metrics.AuthMetrics,
+) (UserAuthServices, error) {
 	// 1. Init Repo
-	userRepository := repositories.NewUserRepository(db)
+	userRepository := repositories.NewUserRepository(cluster)
 
 	// 2. Init Services
-	userService := user.NewService(userRepository)
+	passwordHasher := password.NewService(password.Argon2idParams{
+		Memory:      cfg.Password.Argon2Memory,
+		Iterations:  cfg.Password.Argon2Iterations,
+		Parallelism: cfg.Password.Argon2Parallelism,
+		SaltLength:  cfg.Password.Argon2SaltLength,
+		KeyLength:   cfg.Password.Argon2KeyLength,
+	}, cfg.Password.MaxConcurrentHashes)
+
+	passwordPolicy, breachedCorpus, err := newPasswordPolicy(cfg.Password)
+	if err != nil {
+		return UserAuthServices{}, fmt.Errorf("new password policy: %w", err)
+	}
+
+	userService := user.NewService(userRepository, passwordHasher, passwordPolicy, auditService)
 	tokenService := token.NewService(
 		time.Now,
 		cfg.Auth.AccessTokenDuration,
 		cfg.Auth.RefreshTokenDuration,
 		[]byte(cfg.Auth.AccessSecret),
 		[]byte(cfg.Auth.RefreshSecret),
+		authMetrics,
 	)
 
-	authService := auth.NewService(userService, tokenService)
+	return UserAuthServices{
+		PasswordHasher: passwordHasher,
+		UserService:    userService,
+		TokenService:   tokenService,
+		breachedCorpus: breachedCorpus,
+	}, nil
+}
+
+// BuildUserAuthModule xây dựng module user-auth bao gồm repository, service và handler.
+func BuildUserAuthModule(
+	cfg config.Config,
+	cluster *db.Cluster,
+	auditService *audit.Service,
+	authMetrics *metrics.AuthMetrics,
+) (module userAuthHandlers, err error) {
+	// 1. Init Repo
+	loginEventRepository := repositories.NewLoginEventRepository(cluster.Primary())
+	oneTimeCodeRepository := repositories.NewOneTimeCodeRepository(cluster.Primary())
 
-	// OIDC Provider for OAuth Service
-	provider, err := oidc.NewProvider(context.Background(), "https://accounts.google.com")
+	// 2. Init Services
+	services, err := BuildUserAuthServices(cfg, cluster, auditService, authMetrics)
+	if err != nil {
+		return userAuthHandlers{}, err
+	}
+	defer func() {
+		if err != nil {
+			services.Close()
+		}
+	}()
+
+	passwordHasher := services.PasswordHasher
+	userService := services.UserService
+	tokenService := services.TokenService
+
+	// 3. Record signing key rotations in the audit log
+	for keyName, fingerprint := range tokenService.KeyFingerprints() {
+		if err := auditService.RecordKeyRotation(context.Background(), keyName, fingerprint); err != nil {
+			return userAuthHandlers{}, fmt.Errorf("record %s key rotation: %w", keyName, err)
+		}
+	}
+
+	loginHistoryService := loginhistory.NewService(time.Now, loginEventRepository, userService, authMetrics)
+
+	authService := auth.NewService(userService, passwordHasher, tokenService, loginHistoryService)
+
+	otpService := otp.NewService(time.Now, otp.Config{
+		Purposes:          []models.OneTimeCodePurpose{models.OneTimeCodePurposeEmailLogin},
+		Secret:            []byte(cfg.OTP.Secret),
+		TTL:               cfg.OTP.CodeTTL,
+		MaxAttempts:       cfg.OTP.MaxAttempts,
+		ThrottleWindow:    cfg.OTP.ThrottleWindow,
+		MaxPerDestination: cfg.OTP.MaxPerDestination,
+		MaxPerIP:          cfg.OTP.MaxPerIP,
+	}, oneTimeCodeRepository)
+
+	mail, err := newMailer(cfg.Mail)
+	if err != nil {
+		return userAuthHandlers{}, fmt.Errorf("new mailer: %w", err)
+	}
+
+	emailLoginService := emaillogin.NewService(
+		time.Now,
+		cfg.OTP.EmailLinkURL,
+		mail,
+		otpService,
+		userService,
+		tokenService,
+		loginHistoryService,
+	)
+
+	if !phone.KnownRegion(cfg.SMS.DefaultRegion) {
+		return userAuthHandlers{}, fmt.Errorf("unknown PHONE_DEFAULT_REGION %q", cfg.SMS.DefaultRegion)
+	}
+
+	smsSender, err := newSMSSender(cfg.SMS)
+	if err != nil {
+		return userAuthHandlers{}, fmt.Errorf("new sms sender: %w", err)
+	}
+
+	// SMS codes get their own, stricter limits
+	smsOTPService := otp.NewService(time.Now, otp.Config{
+		Purposes:          []models.OneTimeCodePurpose{models.OneTimeCodePurposePhoneVerify, models.OneTimeCodePurposePhoneLogin},
+		Secret:            []byte(cfg.OTP.Secret),
+		TTL:               cfg.OTP.CodeTTL,
+		MaxAttempts:       cfg.OTP.MaxAttempts,
+		ThrottleWindow:    cfg.OTP.ThrottleWindow,
+		MaxPerDestination: cfg.SMS.MaxPerNumber,
+		MaxPerIP:          cfg.SMS.MaxPerIP,
+	}, oneTimeCodeRepository)
+
+	phoneLoginService := phonelogin.NewService(
+		time.Now,
+		cfg.SMS.DefaultRegion,
+		smsSender,
+		smsOTPService,
+		userService,
+		tokenService,
+		loginHistoryService,
+	)
+
+	impersonationService := impersonation.NewService(cfg.Auth.ImpersonationTokenDuration, userService, tokenService, auditService)
+
+	// OIDC Provider for OAuth Service; discovery and key fetches are traced
+	oidcContext := oidc.ClientContext(context.Background(), tracing.NewClient(10*time.Second))
+	provider, err := oidc.NewProvider(oidcContext, "https://accounts.google.com")
 	if err != nil {
 		return userAuthHandlers{}, err
 	}
 	verifier := provider.Verifier(&oidc.Config{ClientID: cfg.OAuth.ClientID})
-	oAuthService := oauth.NewService(verifier, tokenService, userService)
+
+	var providerClaims struct {
+		JWKSURL string `json:"jwks_uri"`
+	}
+	if err := provider.Claims(&providerClaims); err != nil {
+		return userAuthHandlers{}, fmt.Errorf("read oidc provider claims: %w", err)
+	}
+	oAuthService := oauth.NewService(verifier, tokenService, userService, loginHistoryService)
 
 	// 4. Init Handlers
 	authHandler := handlers.NewAuthHandler(authService)
 	oAuthHandler := handlers.NewOAuthHandler(oAuthService)
 	registerHandler := handlers.NewRegisterHandler(userService)
+	loginHistoryHandler := handlers.NewLoginHistoryHandler(loginHistoryService)
+	userAdminHandler := handlers.NewUserAdminHandler(userService)
+	passwordHandler := handlers.NewPasswordHandler(userService)
+	emailLoginHandler := handlers.NewEmailLoginHandler(emailLoginService)
+	phoneHandler := handlers.NewPhoneHandler(phoneLoginService)
+	impersonationHandler := handlers.NewImpersonationHandler(impersonationService)
 
 	return userAuthHandlers{
-		AuthHandler:     authHandler,
-		OAuthHandler:    oAuthHandler,
-		RegisterHandler: registerHandler,
+		AuthHandler:          authHandler,
+		OAuthHandler:         oAuthHandler,
+		RegisterHandler:      registerHandler,
+		LoginHistoryHandler:  loginHistoryHandler,
+		UserAdminHandler:     userAdminHandler,
+		PasswordHandler:      passwordHandler,
+		EmailLoginHandler:    emailLoginHandler,
+		PhoneHandler:         phoneHandler,
+		ImpersonationHandler: impersonationHandler,
+		HealthChecks: []health.Check{
+			health.KeySetCheck(time.Now, tracing.NewClient(10*time.Second), providerClaims.JWKSURL, 5*time.Minute),
+		},
+		services: services,
 	}, nil
 }
+
+// newPasswordPolicy trả về cả breached corpus đã mở (nếu có) để caller đóng khi dừng.
+func newPasswordPolicy(cfg config.PasswordConfig) (*policy.Policy, *policy.BreachedCorpus, error) {
+	policyConfig := policy.Config{
+		MinLength:      cfg.MinLength,
+		MaxLength:      cfg.MaxLength,
+		BannedPatterns: cfg.BannedPatterns,
+		MaxSimilarity:  cfg.MaxSimilarity,
+		MinScore:       cfg.MinStrength,
+	}
+
+	if cfg.BreachedCorpusFile == "" {
+		passwordPolicy, err := policy.New(policyConfig, nil)
+
+		return passwordPolicy, nil, err
+	}
+
+	breached, err := policy.OpenBreachedCorpus(cfg.BreachedCorpusFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	passwordPolicy, err := policy.New(policyConfig, breached)
+	if err != nil {
+		breached.Close()
+
+		return nil, nil, err
+	}
+
+	return passwordPolicy, breached, nil
+}
+
+func newMailer(cfg config.MailConfig) (mailer.Mailer, error) {
+	switch cfg.Driver {
+	case "log":
+		return mailer.NewLogMailer(), nil
+	case "smtp":
+		return mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+func newSMSSender(cfg config.SMSConfig) (sms.SMSSender, error) {
+	switch cfg.Driver {
+	case "log":
+		return sms.NewLogSender(), nil
+	case "file":
+		return sms.NewFileSender(time.Now, cfg.File), nil
+	default:
+		return nil, fmt.Errorf("unknown sms driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/cmd/service/modulebuilder"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// seedFile is the YAML layout read by the seed command. See deploy/seed.example.yaml.
+type seedFile struct {
+	Users []seedUser `yaml:"users"`
+}
+
+type seedUser struct {
+	Email    string `yaml:"email"`
+	Name     string `yaml:"name"`
+	Password string `yaml:"password"`
+	Role     string `yaml:"role"`
+	Banned   bool   `yaml:"banned"`
+}
+
+// seedUserService creates the seeded users.
+type seedUserService interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	CreateUser(ctx context.Context, email, name, password, role string) (models.User, error)
+	Ban(ctx context.Context, id uuid.UUID) error
+}
+
+// seed loads users from a YAML file. Users whose email already exists are skipped, so seeding twice
+// is safe.
+func seed(cfg config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	file := flags.String("file", "", "YAML seed file (required)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("--file is required: %w", flag.ErrHelp)
+	}
+
+	data, err := readSeedFile(*file)
+	if err != nil {
+		return err
+	}
+
+	return withUserAuthServices(cfg, func(services modulebuilder.UserAuthServices) error {
+		return seedUsers(adminContext(), services.UserService, data.Users, os.Stdout)
+	})
+}
+
+func readSeedFile(path string) (seedFile, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return seedFile{}, fmt.Errorf("read seed file: %w", err)
+	}
+
+	var data seedFile
+	if err := yaml.Unmarshal(raw, &data); err != nil {
+		return seedFile{}, fmt.Errorf("parse seed file: %w", err)
+	}
+
+	return data, nil
+}
+
+// seedUsers creates the users that do not exist yet and reports each to out. A banned user is
+// created and banned in one transaction, so it is never active.
+func seedUsers(ctx context.Context, userService seedUserService, users []seedUser, out io.Writer) error {
+	for i, seeded := range users {
+		_, err := userService.GetUserByEmail(ctx, seeded.Email)
+		if err == nil {
+			fmt.Fprintf(out, "Skipped %s, already exists\n", seeded.Email)
+
+			continue
+		} else if !errors.Is(err, models.ErrUserNotFound) {
+			return fmt.Errorf("get user %d: %w", i, err)
+		}
+
+		role := seeded.Role
+		if role == "" {
+			role = models.RoleUser
+		}
+
+		var user models.User
+		err = userService.Transaction(ctx, func(ctx context.Context) error {
+			user, err = userService.CreateUser(ctx, seeded.Email, seeded.Name, seeded.Password, role)
+			if err != nil {
+				return fmt.Errorf("create user %s: %w", seeded.Email, err)
+			}
+
+			if seeded.Banned {
+				if err := userService.Ban(ctx, user.ID); err != nil {
+					return fmt.Errorf("ban user %s: %w", seeded.Email, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Created %s (%s)\n", user.Email, user.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"testing"
+	"time"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDuplicateKey = errors.New("duplicate key value violates unique constraint")
+
+// memoryUserRepository keeps the unique email and username constraints of the users table.
+// Transactions roll back on error.
+type memoryUserRepository struct {
+	users map[uuid.UUID]models.User
+	// failBan makes status updates fail, to exercise rollbacks.
+	failBan bool
+}
+
+func (r *memoryUserRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	snapshot := maps.Clone(r.users)
+	if err := fn(ctx); err != nil {
+		r.users = snapshot
+		return err
+	}
+
+	return nil
+}
+
+func (r *memoryUserRepository) Create(_ context.Context, created *models.User) error {
+	for _, existing := range r.users {
+		if existing.Email == created.Email || created.Username != "" && existing.Username == created.Username {
+			return errDuplicateKey
+		}
+	}
+
+	created.ID = uuid.New()
+	if created.Status == "" {
+		created.Status = models.StatusActive
+	}
+	r.users[created.ID] = *created
+
+	return nil
+}
+
+func (r *memoryUserRepository) GetByID(_ context.Context, id uuid.UUID) (models.User, error) {
+	found, ok := r.users[id]
+	if !ok {
+		return models.User{}, models.ErrUserNotFound
+	}
+
+	return found, nil
+}
+
+func (r *memoryUserRepository) GetUserByEmail(_ context.Context, email string) (models.User, error) {
+	for _, existing := range r.users {
+		if existing.Email == email {
+			return existing, nil
+		}
+	}
+
+	return models.User{}, models.ErrUserNotFound
+}
+
+func (r *memoryUserRepository) GetByVerifiedPhone(context.Context, string) (models.User, error) {
+	return models.User{}, models.ErrUserNotFound
+}
+
+func (r *memoryUserRepository) CreateUserAndOAuthProvider(ctx context.Context, created *models.User, _ *models.OAuthProviders) error {
+	return r.Create(ctx, created)
+}
+
+func (r *memoryUserRepository) UpdateLastLoginAt(context.Context, uuid.UUID, time.Time) error {
+	return nil
+}
+
+func (r *memoryUserRepository) UpdateStatus(_ context.Context, id uuid.UUID, status string) error {
+	if r.failBan {
+		return errors.New("connection reset")
+	}
+
+	updated := r.users[id]
+	updated.Status = status
+	r.users[id] = updated
+
+	return nil
+}
+
+func (r *memoryUserRepository) UpdateRole(context.Context, uuid.UUID, string) error {
+	return nil
+}
+
+func (r *memoryUserRepository) UpdatePasswordHash(context.Context, uuid.UUID, string) error {
+	return nil
+}
+
+func (r *memoryUserRepository) VerifyEmail(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (r *memoryUserRepository) SetVerifiedPhone(context.Context, uuid.UUID, string, time.Time) error {
+	return nil
+}
+
+type plainHasher struct{}
+
+func (plainHasher) Hash(_ context.Context, password string) (string, error) {
+	return "plain:" + password, nil
+}
+
+func (plainHasher) Verify(context.Context, string, string) (bool, error) {
+	return false, nil
+}
+
+type acceptingPolicy struct{}
+
+func (acceptingPolicy) Check(string, ...string) error {
+	return nil
+}
+
+type discardAuditor struct{}
+
+func (discardAuditor) Record(context.Context, audit.Actor, audit.Action, audit.Target, any) error {
+	return nil
+}
+
+func newSeedUserService(repository *memoryUserRepository) *user.Service {
+	return user.NewService(repository, plainHasher{}, acceptingPolicy{}, discardAuditor{})
+}
+
+func TestSeedExampleFile(t *testing.T) {
+	t.Parallel()
+
+	data, err := readSeedFile("../../deploy/seed.example.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, data.Users)
+
+	repository := &memoryUserRepository{users: map[uuid.UUID]models.User{}}
+	service := newSeedUserService(repository)
+
+	require.NoError(t, seedUsers(t.Context(), service, data.Users, io.Discard))
+	require.Len(t, repository.users, len(data.Users))
+
+	for _, seeded := range data.Users {
+		created, err := repository.GetUserByEmail(t.Context(), seeded.Email)
+		require.NoError(t, err)
+
+		wantRole := seeded.Role
+		if wantRole == "" {
+			wantRole = models.RoleUser
+		}
+		assert.Equal(t, wantRole, created.Role, seeded.Email)
+		assert.Equal(t, seeded.Banned, created.Status == models.StatusBanned, seeded.Email)
+	}
+
+	require.NoError(t, seedUsers(t.Context(), service, data.Users, io.Discard), "seeding twice skips existing users")
+	assert.Len(t, repository.users, len(data.Users))
+}
+
+func TestSeedBanRollsBackUser(t *testing.T) {
+	t.Parallel()
+
+	repository := &memoryUserRepository{users: map[uuid.UUID]models.User{}, failBan: true}
+	service := newSeedUserService(repository)
+
+	err := seedUsers(t.Context(), service, []seedUser{{Email: "banned@example.com", Password: "password", Banned: true}}, io.Discard)
+	require.Error(t, err)
+	assert.Empty(t, repository.users, "a user that could not be banned is not left active")
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/cmd/service/modulebuilder"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/metrics"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/tracing"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/redact"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/problem"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/routes"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/health"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+const shutdownTimeout = 20 * time.Second
+
+// serve runs the HTTP server until the process receives a termination signal.
+func serve(cfg config.Config, logging *slogx.Logging, args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	migrateOnStart := flags.Bool("migrate-on-start", cfg.DB.MigrateOnStart, "apply pending migrations before serving")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Init tracing
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("init tracing: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("Tracing shutdown error", "err", err.Error())
+		}
+	}()
+
+	traceStarter := slogx.NewTraceStarter(uuid.NewV7)
+
+	// Init DB connection
+	gormDB, err := db.NewGormDB(context.Background(), cfg.DB)
+	if err != nil {
+		return fmt.Errorf("new db connection: %w", err)
+	}
+	defer func() {
+		dbConnection, _ := gormDB.DB()
+		dbConnection.Close()
+	}()
+
+	queryMetrics := db.NewQueryMetrics(prometheus.DefaultRegisterer)
+	if err := queryMetrics.Instrument(gormDB, "primary"); err != nil {
+		return fmt.Errorf("instrument db: %w", err)
+	}
+
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		return fmt.Errorf("get sql db: %w", err)
+	}
+
+	migrator, err := db.NewMigrator(sqlDB)
+	if err != nil {
+		return fmt.Errorf("new migrator: %w", err)
+	}
+
+	if *migrateOnStart {
+		if err := migrator.Up(context.Background()); err != nil {
+			return fmt.Errorf("migrate on start: %w", err)
+		}
+	}
+
+	// Route reads to replicas
+	replicas := make([]*gorm.DB, 0, len(cfg.DB.ReplicaURLs))
+	for i, url := range cfg.DB.ReplicaURLs {
+		replica, err := db.NewReplicaDB(cfg.DB, url)
+		if err != nil {
+			return fmt.Errorf("new db replica connection: %w", err)
+		}
+		defer func() {
+			replicaConnection, _ := replica.DB()
+			replicaConnection.Close()
+		}()
+
+		if err := queryMetrics.Instrument(replica, "replica-"+strconv.Itoa(i)); err != nil {
+			return fmt.Errorf("instrument db replica: %w", err)
+		}
+
+		replicas = append(replicas, replica)
+	}
+
+	cluster := db.NewCluster(time.Now, db.ClusterConfig{
+		CheckInterval: cfg.DB.ReplicaCheckInterval,
+		MaxLag:        cfg.DB.ReplicaMaxLag,
+		PinWindow:     cfg.DB.ReadYourWritesWindow,
+		Registerer:    prometheus.DefaultRegisterer,
+	}, gormDB, replicas...)
+
+	clusterCtx, stopCluster := context.WithCancel(context.Background())
+	defer stopCluster()
+
+	go cluster.Run(clusterCtx)
+
+	auditModule := modulebuilder.BuildAuditModule(gormDB)
+
+	authMetrics := metrics.NewAuthMetrics(prometheus.DefaultRegisterer)
+	metrics.RegisterLogDrops(prometheus.DefaultRegisterer, logging.Dropped)
+
+	userAuthHandlers, err := modulebuilder.BuildUserAuthModule(cfg, cluster, auditModule.Service, authMetrics)
+	if err != nil {
+		return fmt.Errorf("build user-auth module: %w", err)
+	}
+	defer userAuthHandlers.Close()
+
+	challengeModule, err := modulebuilder.BuildChallengeModule(cfg.Challenge)
+	if err != nil {
+		return fmt.Errorf("build challenge module: %w", err)
+	}
+
+	loggingModule, err := modulebuilder.BuildLoggingModule(cfg.Logger, logging.Levels, auditModule.Service)
+	if err != nil {
+		return fmt.Errorf("build logging module: %w", err)
+	}
+
+	healthModule := modulebuilder.BuildHealthModule(cfg.HTTP, append([]health.Check{
+		health.DatabaseCheck(sqlDB),
+		health.MigrationCheck(migrator),
+	}, userAuthHandlers.HealthChecks...)...)
+
+	// Configure middleware with the custom claims type
+	echoJWTConfig := echojwt.Config{
+		NewClaimsFunc: func(echo.Context) jwt.Claims {
+			return new(token.JwtCustomClaims)
+		},
+		SigningKey: []byte(cfg.Auth.AccessSecret),
+		// Missing, malformed and expired tokens are all answered as invalid_token
+		ErrorHandler: func(_ echo.Context, err error) error {
+			return errors.Join(models.ErrInvalidAuthToken, err)
+		},
+	}
+
+	trustedProxies, err := server.ParseTrustedProxies(cfg.HTTP.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("parse trusted proxies: %w", err)
+	}
+
+	allHandlers := routes.Handlers{
+		AuthHandler:     userAuthHandlers.AuthHandler,
+		OAuthHandler:    userAuthHandlers.OAuthHandler,
+		RegisterHandler: userAuthHandlers.RegisterHandler,
+
+		EmailLoginHandler: userAuthHandlers.EmailLoginHandler,
+		PhoneHandler:      userAuthHandlers.PhoneHandler,
+
+		LoginHistoryHandler:  userAuthHandlers.LoginHistoryHandler,
+		UserAdminHandler:     userAuthHandlers.UserAdminHandler,
+		ImpersonationHandler: userAuthHandlers.ImpersonationHandler,
+		PasswordHandler:      userAuthHandlers.PasswordHandler,
+		AuditHandler:         auditModule.AuditHandler,
+		ChallengeHandler:     challengeModule.ChallengeHandler,
+		HealthHandler:        healthModule.HealthHandler,
+		LoggingHandler:       loggingModule.LoggingHandler,
+
+		MetricsMiddleware:         middleware.NewMetrics(prometheus.DefaultRegisterer),
+		TracingMiddleware:         middleware.NewTracing(trustedProxies),
+		DebugEscalationMiddleware: loggingModule.DebugEscalation,
+		EchoJWTMiddleware:         echojwt.WithConfig(echoJWTConfig),
+		ChallengeMiddleware:       challengeModule.Guard,
+		ReadYourWritesMiddleware:  middleware.NewReadYourWrites(cfg.DB.ReadYourWritesWindow),
+
+		RequestDebuggerMiddleware: middleware.NewRequestDebugger(redact.New(cfg.Logger.RedactKeys), middleware.RequestDebuggerConfig{
+			MaxBodySize: cfg.Logger.CaptureMaxBodySize,
+			SampleRatio: cfg.Logger.CaptureSampleRatio,
+			Routes:      cfg.Logger.CaptureRoutes,
+		}),
+	}
+
+	engine := echo.New()
+	engine.HTTPErrorHandler = problem.NewErrorHandler(modulebuilder.BuildProblemRegistry())
+	engine.IPExtractor = server.NewIPExtractor(trustedProxies)
+
+	if err := routes.ConfigureRoutes(traceStarter, engine, allHandlers); err != nil {
+		return fmt.Errorf("configure routes: %w", err)
+	}
+
+	app := server.NewServer(engine)
+	go func() {
+		if err = app.Start(cfg.HTTP.Port); err != nil {
+			slog.Error("Server error", "err", err.Error())
+		}
+	}()
+
+	// Serve metrics on the admin port
+	var adminServer *server.AdminServer
+	if cfg.HTTP.AdminPort != "" {
+		adminServer = server.NewAdminServer(cfg.HTTP.AdminPort)
+		adminServer.Handle("/metrics", promhttp.Handler())
+
+		go func() {
+			if err := adminServer.Start(); err != nil {
+				slog.Error("Admin server error", "err", err.Error())
+			}
+		}()
+	}
+
+	shutdownChannel := make(chan os.Signal, 1)
+	signal.Notify(shutdownChannel, os.Interrupt, syscall.SIGHUP, syscall.SIGTERM)
+	<-shutdownChannel
+
+	// Fail readiness first, so that load balancers stop sending traffic before the server stops
+	healthModule.Service.Drain()
+	slog.Info("Draining before shutdown", "delay", cfg.HTTP.ShutdownDrainDelay.String())
+	time.Sleep(cfg.HTTP.ShutdownDrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := app.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("http server shutdown: %w", err)
+	}
+
+	if adminServer != nil {
+		if err := adminServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("admin server shutdown: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package main
+
+import "github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+
+// reopenOnSignal does nothing: there is no SIGUSR1 outside Unix.
+func reopenOnSignal(*slogx.Logging) func() {
+	return func() {}
+}
@@ -0,0 +1,38 @@
+//go:build unix
+
+package main
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+)
+
+// reopenOnSignal reopens the log files on SIGUSR1 until the returned function is called.
+func reopenOnSignal(logging *slogx.Logging) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-signals:
+				if err := logging.Reopen(); err != nil {
+					slog.Error("Reopen log files", "err", err.Error())
+				}
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
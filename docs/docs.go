@@ -19,9 +19,752 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Page through audit entries matching the filters, newest first. Admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "Query the audit log",
+                "operationId": "admin-audit-list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Actor user ID",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. user.ban",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target type, e.g. user",
+                        "name": "targetType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target ID",
+                        "name": "targetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inclusive lower bound, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exclusive upper bound, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.EntriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit-logs/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Download every audit entry matching the filters in chain order. Admin only",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "Export the audit log as CSV",
+                "operationId": "admin-audit-export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Actor user ID",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. user.ban",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target type, e.g. user",
+                        "name": "targetType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target ID",
+                        "name": "targetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inclusive lower bound, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exclusive upper bound, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit-logs/verify": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Recompute every entry hash and report the first entry that was tampered with. Admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "Verify the audit log hash chain",
+                "operationId": "admin-audit-verify",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.VerifyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/log/debug-tokens": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Issue a token for the X-Debug-Token header, which logs the requests carrying it at DEBUG level until it expires. Admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "Issue a debug token",
+                "operationId": "admin-log-debug-token",
+                "parameters": [
+                    {
+                        "description": "Validity",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.DebugTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responses.DebugTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/log/levels": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the log level and its overrides by package. Admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "Get log levels",
+                "operationId": "admin-log-levels",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.LevelsResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the log level, or override it for a package and its subpackages, until the next restart. Admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "Change a log level",
+                "operationId": "admin-log-level-set",
+                "parameters": [
+                    {
+                        "description": "Package and level",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.SetLevelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.LevelsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/ban": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Prevent a user from logging in or refreshing tokens. Admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "Ban a user",
+                "operationId": "admin-user-ban",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Data"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/impersonate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Issue a short-lived, non-refreshable access token for acting as the user. The token carries the admin in its act claim and cannot change the password, phone number or use admin endpoints. Admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "Impersonate a user",
+                "operationId": "admin-user-impersonate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ImpersonationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/logins": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Page through the login attempts of any user, newest first. Admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "List a user's login history",
+                "operationId": "admin-user-login-history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.LoginEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Assign a new role to a user. Admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "Change a user's role",
+                "operationId": "admin-user-role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.ChangeRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Data"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/unban": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore a banned user's access. Admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Actions"
+                ],
+                "summary": "Unban a user",
+                "operationId": "admin-user-unban",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Data"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenge": {
+            "get": {
+                "description": "Issue a challenge required by sign-up and code requests. Find a solution such that SHA-256(challenge + \":\" + solution) starts with the given number of zero bits, then send both in the X-Challenge and X-Challenge-Solution headers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bot Protection"
+                ],
+                "summary": "Get a proof-of-work challenge",
+                "operationId": "challenge-issue",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ChallengeResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/google-oauth": {
             "post": {
-                "description": "Perform user login using google provider",
+                "description": "Perform user login using google provider",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Actions"
+                ],
+                "summary": "Authenticate user using google provider",
+                "operationId": "user-auth-google",
+                "parameters": [
+                    {
+                        "description": "Google Token",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.OAuthRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Perform user login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Actions"
+                ],
+                "summary": "Authenticate a user",
+                "operationId": "user-login",
+                "parameters": [
+                    {
+                        "description": "User's credentials",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/email-link": {
+            "post": {
+                "description": "Email a one-time login link and 6-digit code, bound to the requesting device",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Actions"
+                ],
+                "summary": "Request an email login link",
+                "operationId": "user-email-link",
+                "parameters": [
+                    {
+                        "description": "Email address and device ID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.EmailLinkRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proof-of-work challenge from GET /challenge",
+                        "name": "X-Challenge",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proof-of-work solution",
+                        "name": "X-Challenge-Solution",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Captcha response token, accepted instead of proof-of-work when configured",
+                        "name": "X-Captcha-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Data"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/email-link/verify": {
+            "post": {
+                "description": "Exchange the emailed link token, or the email address and code, for tokens. Creates the user on first login.",
                 "consumes": [
                     "application/json"
                 ],
@@ -31,16 +774,16 @@ const docTemplate = `{
                 "tags": [
                     "User Actions"
                 ],
-                "summary": "Authenticate user using google provider",
-                "operationId": "user-auth-google",
+                "summary": "Verify an email login link or code",
+                "operationId": "user-email-link-verify",
                 "parameters": [
                     {
-                        "description": "Google Token",
+                        "description": "Link token, or email address and code",
                         "name": "params",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requests.OAuthRequest"
+                            "$ref": "#/definitions/requests.EmailLinkVerifyRequest"
                         }
                     }
                 ],
@@ -51,18 +794,368 @@ const docTemplate = `{
                             "$ref": "#/definitions/responses.LoginResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/responses.Error"
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
                         }
                     }
                 }
             }
         },
-        "/login": {
+        "/login/phone": {
             "post": {
-                "description": "Perform user login",
+                "description": "Send an SMS login code to a verified phone number. Responds the same way whether or not the number is known.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Actions"
+                ],
+                "summary": "Request a phone login code",
+                "operationId": "user-phone-login",
+                "parameters": [
+                    {
+                        "description": "Phone number and device ID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.PhoneRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proof-of-work challenge from GET /challenge",
+                        "name": "X-Challenge",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proof-of-work solution",
+                        "name": "X-Challenge-Solution",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Captcha response token, accepted instead of proof-of-work when configured",
+                        "name": "X-Captcha-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Data"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/phone/verify": {
+            "post": {
+                "description": "Exchange the phone number and SMS code for tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Actions"
+                ],
+                "summary": "Verify a phone login code",
+                "operationId": "user-phone-login-verify",
+                "parameters": [
+                    {
+                        "description": "Phone number, code and device ID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.PhoneVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/logins": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Page through the authenticated user's login attempts, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Actions"
+                ],
+                "summary": "List own login history",
+                "operationId": "user-login-history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.LoginEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/password": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the authenticated user's password after confirming the current one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Actions"
+                ],
+                "summary": "Change password",
+                "operationId": "user-change-password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Data"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Problem"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "violations": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/policy.Violation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/phone": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send an SMS code proving that the authenticated user owns the phone number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Actions"
+                ],
+                "summary": "Request phone verification",
+                "operationId": "user-phone",
+                "parameters": [
+                    {
+                        "description": "Phone number and device ID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.PhoneRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proof-of-work challenge from GET /challenge",
+                        "name": "X-Challenge",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proof-of-work solution",
+                        "name": "X-Challenge-Solution",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Captcha response token, accepted instead of proof-of-work when configured",
+                        "name": "X-Captcha-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Data"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/phone/verify": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Store the phone number as the authenticated user's verified number if the SMS code matches",
                 "consumes": [
                     "application/json"
                 ],
@@ -72,16 +1165,16 @@ const docTemplate = `{
                 "tags": [
                     "User Actions"
                 ],
-                "summary": "Authenticate a user",
-                "operationId": "user-login",
+                "summary": "Verify phone number",
+                "operationId": "user-phone-verify",
                 "parameters": [
                     {
-                        "description": "User's credentials",
+                        "description": "Phone number, code and device ID",
                         "name": "params",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requests.LoginRequest"
+                            "$ref": "#/definitions/requests.PhoneVerifyRequest"
                         }
                     }
                 ],
@@ -89,13 +1182,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responses.LoginResponse"
+                            "$ref": "#/definitions/responses.Data"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/responses.Error"
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
                         }
                     }
                 }
@@ -136,7 +1241,13 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/responses.Error"
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
                         }
                     }
                 }
@@ -165,6 +1276,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/requests.RegisterRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proof-of-work challenge from GET /challenge",
+                        "name": "X-Challenge",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proof-of-work solution",
+                        "name": "X-Challenge-Solution",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Captcha response token, accepted instead of proof-of-work when configured",
+                        "name": "X-Captcha-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -177,7 +1306,34 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responses.Error"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Problem"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "violations": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/policy.Violation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Problem"
                         }
                     }
                 }
@@ -185,6 +1341,102 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "policy.Violation": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "too_short"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Password must be at least 8 characters long"
+                }
+            }
+        },
+        "requests.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "currentPassword",
+                "newPassword"
+            ],
+            "properties": {
+                "currentPassword": {
+                    "type": "string",
+                    "example": "11111111"
+                },
+                "newPassword": {
+                    "type": "string",
+                    "example": "correct horse battery staple"
+                }
+            }
+        },
+        "requests.ChangeRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "MODERATOR"
+                }
+            }
+        },
+        "requests.DebugTokenRequest": {
+            "type": "object",
+            "required": [
+                "ttl"
+            ],
+            "properties": {
+                "ttl": {
+                    "description": "How long the token is valid, as a Go duration.",
+                    "type": "string",
+                    "example": "30m"
+                }
+            }
+        },
+        "requests.EmailLinkRequest": {
+            "type": "object",
+            "required": [
+                "deviceId",
+                "email"
+            ],
+            "properties": {
+                "deviceId": {
+                    "type": "string",
+                    "example": "8f14e45f-ceea-467f-a0e6-1f2a3b4c5d6e"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                }
+            }
+        },
+        "requests.EmailLinkVerifyRequest": {
+            "type": "object",
+            "required": [
+                "deviceId"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "example": "8f14e45f-ceea-467f-a0e6-1f2a3b4c5d6e"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "link_token"
+                }
+            }
+        },
         "requests.LoginRequest": {
             "type": "object",
             "required": [
@@ -213,6 +1465,45 @@ const docTemplate = `{
                 }
             }
         },
+        "requests.PhoneRequest": {
+            "type": "object",
+            "required": [
+                "deviceId",
+                "phone"
+            ],
+            "properties": {
+                "deviceId": {
+                    "type": "string",
+                    "example": "8f14e45f-ceea-467f-a0e6-1f2a3b4c5d6e"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+84912345678"
+                }
+            }
+        },
+        "requests.PhoneVerifyRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "deviceId",
+                "phone"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "example": "8f14e45f-ceea-467f-a0e6-1f2a3b4c5d6e"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+84912345678"
+                }
+            }
+        },
         "requests.RefreshRequest": {
             "type": "object",
             "required": [
@@ -247,6 +1538,42 @@ const docTemplate = `{
                 }
             }
         },
+        "requests.SetLevelRequest": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "description": "DEBUG, INFO, WARN or ERROR, optionally with an offset such as INFO+2. Empty removes the\noverride of the package.",
+                    "type": "string",
+                    "example": "DEBUG"
+                },
+                "package": {
+                    "description": "Import path of a package whose level is overridden. The default level if empty.",
+                    "type": "string",
+                    "example": "github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db"
+                }
+            }
+        },
+        "responses.ChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "example": "sha256"
+                },
+                "challenge": {
+                    "description": "Send back in the X-Challenge header, with the solution in X-Challenge-Solution.",
+                    "type": "string"
+                },
+                "difficulty": {
+                    "description": "Number of leading zero bits required in SHA-256(challenge + \":\" + solution).",
+                    "type": "integer",
+                    "example": 18
+                },
+                "expiresAt": {
+                    "type": "string"
+                }
+            }
+        },
         "responses.Data": {
             "type": "object",
             "properties": {
@@ -258,17 +1585,157 @@ const docTemplate = `{
                 }
             }
         },
-        "responses.Error": {
+        "responses.DebugTokenResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "description": "Send in the X-Debug-Token header to log the request at DEBUG level.",
+                    "type": "string"
+                }
+            }
+        },
+        "responses.EntriesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.Entry"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "responses.Entry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "user.ban"
+                },
+                "actorId": {
+                    "type": "string"
+                },
+                "actorType": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "diff": {
+                    "type": "object"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "targetId": {
+                    "type": "string"
+                },
+                "targetType": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "traceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "responses.ImpersonationResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "exp": {
                     "type": "integer"
+                }
+            }
+        },
+        "responses.LevelsResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "description": "Level of packages without override.",
+                    "type": "string",
+                    "example": "INFO"
+                },
+                "overrides": {
+                    "description": "Levels by package import path.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "responses.LoginEvent": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "failureReason": {
+                    "type": "string",
+                    "example": "invalid_password"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "password"
+                },
+                "outcome": {
+                    "type": "string",
+                    "example": "success"
                 },
-                "error": {
+                "userAgent": {
                     "type": "string"
                 }
             }
         },
+        "responses.LoginEventsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.LoginEvent"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "responses.LoginResponse": {
             "type": "object",
             "properties": {
@@ -282,6 +1749,59 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "responses.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "user_not_found"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "Errors maps the invalid fields of a request to what is wrong with them.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/external/v1/admin/users/4b3c2a1d-0000-0000-0000-000000000000/ban"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "User not found"
+                },
+                "traceId": {
+                    "type": "string",
+                    "example": "4bf92f3577b34da6a3ce929d0e0e4736"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/user_not_found"
+                }
+            }
+        },
+        "responses.VerifyResponse": {
+            "type": "object",
+            "properties": {
+                "brokenAt": {
+                    "type": "integer"
+                },
+                "checked": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -303,6 +1823,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "API for user authentication and management.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// fileSuffix marks a variable holding the path of a file with the value, e.g. ACCESS_SECRET_FILE.
+const fileSuffix = "_FILE"
+
+// LoadOptions selects the layers read by [Load] in addition to the defaults and the environment.
+type LoadOptions struct {
+	// File is an optional YAML or TOML file, chosen by extension. Keys are variable names, either
+	// flat (DB_HOST) or nested by prefix (db: {host: ...}).
+	File string
+
+	// Overrides are KEY=VALUE pairs, usually given with --set flags.
+	Overrides []string
+}
+
+// Load builds the configuration from, in increasing precedence: defaults, the file, the environment
+// and the overrides. In every layer, KEY_FILE loads KEY from the named file, for secrets mounted by
+// the orchestrator. The result is validated.
+func Load(environ []string, options LoadOptions) (Config, error) {
+	fields := describeFields(&Config{})
+
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.Key] = true
+	}
+
+	values := make(map[string]string)
+
+	var errs []error
+
+	if options.File != "" {
+		layer, err := readFile(options.File)
+		if err != nil {
+			return Config{}, err
+		}
+
+		errs = append(errs, mergeLayer(values, layer, known, "file "+options.File, true)...)
+	}
+
+	environment := make(map[string]string, len(environ))
+	for _, variable := range environ {
+		key, value, _ := strings.Cut(variable, "=")
+		environment[key] = value
+	}
+
+	errs = append(errs, mergeLayer(values, environment, known, "environment", false)...)
+
+	overrides := make(map[string]string, len(options.Overrides))
+	for _, override := range options.Overrides {
+		key, value, ok := strings.Cut(override, "=")
+		if !ok {
+			errs = append(errs, fmt.Errorf("override %q: expected KEY=VALUE", override))
+
+			continue
+		}
+
+		overrides[key] = value
+	}
+
+	errs = append(errs, mergeLayer(values, overrides, known, "overrides", true)...)
+
+	if len(errs) > 0 {
+		return Config{}, errors.Join(errs...)
+	}
+
+	var cfg Config
+	if err := env.ParseWithOptions(&cfg, env.Options{Environment: values}); err != nil {
+		return Config{}, fmt.Errorf("parse config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// mergeLayer copies known keys of layer into values, resolving KEY_FILE references. Unknown keys are
+// reported if strict; the environment is not strict because it holds unrelated variables.
+func mergeLayer(values, layer map[string]string, known map[string]bool, name string, strict bool) []error {
+	var errs []error
+
+	for key, value := range layer {
+		if known[key] {
+			values[key] = value
+
+			continue
+		}
+
+		base, isFile := strings.CutSuffix(key, fileSuffix)
+		if !isFile || !known[base] {
+			if strict {
+				errs = append(errs, fmt.Errorf("%s: unknown key %s", name, key))
+			}
+
+			continue
+		}
+
+		// An explicit value in the same layer wins over the file.
+		if _, ok := layer[base]; ok {
+			continue
+		}
+
+		content, err := os.ReadFile(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: read %s: %w", name, key, err))
+
+			continue
+		}
+
+		values[base] = strings.TrimRight(string(content), "\r\n")
+	}
+
+	return errs
+}
+
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var tree map[string]any
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &tree)
+	case ".toml":
+		err = toml.Unmarshal(content, &tree)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	layer := make(map[string]string)
+	flatten(layer, "", tree)
+
+	return layer, nil
+}
+
+// flatten turns nested keys into variable names: {db: {host: x}} becomes DB_HOST=x. Lists are
+// joined with commas.
+func flatten(layer map[string]string, prefix string, tree map[string]any) {
+	for key, value := range tree {
+		key = strings.ToUpper(prefix + key)
+
+		switch value := value.(type) {
+		case map[string]any:
+			flatten(layer, key+"_", value)
+		case []any:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+
+			layer[key] = strings.Join(items, ",")
+		case nil:
+			layer[key] = ""
+		default:
+			layer[key] = fmt.Sprint(value)
+		}
+	}
+}
+
+type fieldDescription struct {
+	Key    string
+	Secret bool
+	Value  reflect.Value
+	Field  reflect.StructField
+}
+
+// describeFields lists the fields of the configuration that are read from variables, in declaration order.
+func describeFields(cfg *Config) []fieldDescription {
+	var fields []fieldDescription
+
+	var walk func(value reflect.Value)
+	walk = func(value reflect.Value) {
+		for i := range value.NumField() {
+			field := value.Type().Field(i)
+
+			if field.Type.Kind() == reflect.Struct && field.Tag.Get("env") == "" {
+				walk(value.Field(i))
+
+				continue
+			}
+
+			key, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+			if key == "" {
+				continue
+			}
+
+			fields = append(fields, fieldDescription{
+				Key:    key,
+				Secret: field.Tag.Get("secret") == "true",
+				Value:  value.Field(i),
+				Field:  field,
+			})
+		}
+	}
+
+	walk(reflect.ValueOf(cfg).Elem())
+
+	return fields
+}
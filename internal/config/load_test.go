@@ -0,0 +1,153 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validEnviron holds every required variable.
+func validEnviron() []string {
+	return []string{
+		"ACCESS_SECRET=access",
+		"REFRESH_SECRET=refresh",
+		"OPEN_ID_CLIENT_ID=client",
+		"OTP_SECRET=otp",
+		"CHALLENGE_SECRET=challenge",
+		"DB_HOST=localhost",
+		"DB_PORT=5432",
+		"DB_USER=user",
+		"DB_NAME=name",
+		"PORT=7788",
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	t.Parallel()
+
+	file := writeFile(t, "config.yaml", "db:\n  host: file-host\n  name: file-name\nport: 8000\nlog_level: WARN\n")
+	environ := append(validEnviron(), "DB_HOST=env-host", "UNRELATED=ignored")
+
+	cfg, err := config.Load(environ, config.LoadOptions{File: file, Overrides: []string{"PORT=9000"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "env-host", cfg.DB.Host)
+	assert.Equal(t, "name", cfg.DB.Name)
+	assert.Equal(t, "9000", cfg.HTTP.Port)
+	assert.Equal(t, "WARN", cfg.Logger.Level)
+	assert.Equal(t, 18, cfg.Challenge.Difficulty)
+}
+
+func TestLoadTOML(t *testing.T) {
+	t.Parallel()
+
+	file := writeFile(t, "config.toml", "[challenge]\ndifficulty = 20\nallow_cidrs = [\"10.0.0.0/8\", \"127.0.0.1/32\"]\n")
+
+	cfg, err := config.Load(validEnviron(), config.LoadOptions{File: file})
+	require.NoError(t, err)
+
+	assert.Equal(t, 20, cfg.Challenge.Difficulty)
+	assert.Equal(t, []string{"10.0.0.0/8", "127.0.0.1/32"}, cfg.Challenge.AllowCIDRs)
+}
+
+func TestLoadSecretFile(t *testing.T) {
+	t.Parallel()
+
+	secret := writeFile(t, "access", "from-file\n")
+	environ := append(validEnviron(), "ACCESS_SECRET_FILE="+secret)
+
+	cfg, err := config.Load(environ, config.LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "access", cfg.Auth.AccessSecret, "explicit value in the same layer wins")
+
+	cfg, err = config.Load(environ, config.LoadOptions{Overrides: []string{"ACCESS_SECRET_FILE=" + secret}})
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", cfg.Auth.AccessSecret)
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Parallel()
+
+	file := writeFile(t, "config.yaml", "unknown_key: 1\n")
+
+	_, err := config.Load(validEnviron(), config.LoadOptions{File: file, Overrides: []string{"PORT", "NOPE=1"}})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "unknown key UNKNOWN_KEY")
+	assert.ErrorContains(t, err, `override "PORT"`)
+	assert.ErrorContains(t, err, "unknown key NOPE")
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	environ := []string{
+		"APP_ENV=production",
+		"ACCESS_SECRET=same",
+		"REFRESH_SECRET=same",
+		"LOG_LEVEL=LOUD",
+		"CAPTCHA_PROVIDER=static",
+		"CHALLENGE_DIFFICULTY=40",
+		"OTP_MAX_ATTEMPTS=0",
+		"AUTH_ALLOW_TOKEN_MINT=true",
+		"PASSWORD_ARGON2_ITERATIONS=0",
+		"PASSWORD_ARGON2_PARALLELISM=4",
+		"PASSWORD_ARGON2_MEMORY=16",
+		"PASSWORD_ARGON2_KEY_LENGTH=8",
+	}
+
+	_, err := config.Load(environ, config.LoadOptions{})
+	require.Error(t, err)
+
+	for _, problem := range []string{
+		"ACCESS_SECRET and REFRESH_SECRET must differ",
+		"LOG_LEVEL",
+		"OTP_SECRET: required",
+		"CHALLENGE_SECRET: required",
+		"CAPTCHA_STATIC_TOKEN: required",
+		"CHALLENGE_DIFFICULTY",
+		"OTP_MAX_ATTEMPTS: must be positive",
+		"DB_HOST: required",
+		"PORT: required",
+		"MAIL_DRIVER: the log driver is not allowed in production",
+		"SMS_DRIVER: the log driver is not allowed in production",
+		"CAPTCHA_PROVIDER: the static provider is not allowed in production",
+		"AUTH_ALLOW_TOKEN_MINT: token minting is not allowed in production",
+		"PASSWORD_ARGON2_ITERATIONS: must be positive",
+		"PASSWORD_ARGON2_MEMORY: must be at least 32",
+		"PASSWORD_ARGON2_KEY_LENGTH: must be at least 16",
+	} {
+		assert.ErrorContains(t, err, problem)
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	environ := append(validEnviron(), "PASSWORD_BANNED_PATTERNS=a;b")
+
+	cfg, err := config.Load(environ, config.LoadOptions{})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, config.Print(&out, cfg))
+
+	assert.Contains(t, out.String(), "ACCESS_SECRET=[REDACTED]\n")
+	assert.Contains(t, out.String(), "DB_PASSWORD=\n")
+	assert.Contains(t, out.String(), "DB_HOST=localhost\n")
+	assert.Contains(t, out.String(), "PASSWORD_BANNED_PATTERNS=a;b\n")
+	assert.NotContains(t, out.String(), "access")
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Print writes the configuration as KEY=VALUE lines, one per variable, with secrets redacted.
+func Print(w io.Writer, cfg Config) error {
+	for _, field := range describeFields(&cfg) {
+		value := formatValue(field)
+		if field.Secret && value != "" {
+			value = redacted
+		}
+
+		if _, err := fmt.Fprintf(w, "%s=%s\n", field.Key, value); err != nil {
+			return fmt.Errorf("write %s: %w", field.Key, err)
+		}
+	}
+
+	return nil
+}
+
+func formatValue(field fieldDescription) string {
+	if field.Value.Kind() != reflect.Slice {
+		return fmt.Sprint(field.Value.Interface())
+	}
+
+	separator := field.Field.Tag.Get("envSeparator")
+	if separator == "" {
+		separator = ","
+	}
+
+	items := make([]string, 0, field.Value.Len())
+	for i := range field.Value.Len() {
+		items = append(items, fmt.Sprint(field.Value.Index(i).Interface()))
+	}
+
+	return strings.Join(items, separator)
+}
@@ -0,0 +1,270 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"time"
+)
+
+// Validate reports every problem in the configuration at once.
+func (c Config) Validate() error {
+	var v validator
+
+	v.oneOf("APP_ENV", c.App.Environment, "development", "staging", "production")
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Logger.Level)); err != nil {
+		v.addf("LOG_LEVEL: unknown level %q", c.Logger.Level)
+	}
+
+	for _, output := range c.Logger.Outputs {
+		if v.oneOf("LOG_OUTPUTS", output, "stdout", "file") && output == "file" {
+			v.required("LOG_FILE", c.Logger.File)
+		}
+	}
+
+	v.oneOf("LOG_FORMAT", c.Logger.Format, "json", "logfmt", "pretty")
+	v.oneOf("LOG_FILE_FORMAT", c.Logger.FileFormat, "json", "logfmt")
+
+	if err := level.UnmarshalText([]byte(c.Logger.StdoutLevel)); c.Logger.StdoutLevel != "" && err != nil {
+		v.addf("LOG_STDOUT_LEVEL: unknown level %q", c.Logger.StdoutLevel)
+	}
+
+	if err := level.UnmarshalText([]byte(c.Logger.FileLevel)); c.Logger.FileLevel != "" && err != nil {
+		v.addf("LOG_FILE_LEVEL: unknown level %q", c.Logger.FileLevel)
+	}
+
+	if c.Logger.FileMaxSize < 0 || c.Logger.FileRotateEvery < 0 || c.Logger.FileMaxBackups < 0 || c.Logger.FileMaxAge < 0 {
+		v.addf("LOG_FILE_MAX_SIZE, LOG_FILE_ROTATE_EVERY, LOG_FILE_MAX_BACKUPS and LOG_FILE_MAX_AGE: must not be negative")
+	}
+
+	if c.Logger.BufferSize < 0 {
+		v.addf("LOG_BUFFER_SIZE: must not be negative, got %d", c.Logger.BufferSize)
+	}
+
+	v.positive("LOG_DEBUG_TOKEN_MAX_TTL", c.Logger.DebugTokenMaxTTL)
+
+	if c.Logger.CaptureMaxBodySize < 0 {
+		v.addf("LOG_CAPTURE_MAX_BODY_SIZE: must not be negative, got %d", c.Logger.CaptureMaxBodySize)
+	}
+
+	if c.Logger.CaptureSampleRatio < 0 || c.Logger.CaptureSampleRatio > 1 {
+		v.addf("LOG_CAPTURE_SAMPLE_RATIO: must be in [0, 1], got %g", c.Logger.CaptureSampleRatio)
+	}
+
+	if v.oneOf("TRACING_EXPORTER", c.Tracing.Exporter, "", "otlp", "stdout", "file") {
+		switch c.Tracing.Exporter {
+		case "otlp":
+			v.required("TRACING_OTLP_ENDPOINT", c.Tracing.OTLPEndpoint)
+		case "file":
+			v.required("TRACING_FILE", c.Tracing.File)
+		}
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		v.addf("TRACING_SAMPLE_RATIO: must be in [0, 1], got %g", c.Tracing.SampleRatio)
+	}
+
+	v.required("ACCESS_SECRET", c.Auth.AccessSecret)
+	v.required("REFRESH_SECRET", c.Auth.RefreshSecret)
+
+	if c.Auth.AccessSecret != "" && c.Auth.AccessSecret == c.Auth.RefreshSecret {
+		v.addf("ACCESS_SECRET and REFRESH_SECRET must differ")
+	}
+
+	v.positive("ACCESS_SECRET_DURATION", c.Auth.AccessTokenDuration)
+	v.positive("REFRESH_SECRET_DURATION", c.Auth.RefreshTokenDuration)
+	v.positive("IMPERSONATION_TOKEN_DURATION", c.Auth.ImpersonationTokenDuration)
+
+	// Zero Argon2 costs make hashing panic; short salts and keys weaken every stored hash
+	v.positiveInt("PASSWORD_ARGON2_ITERATIONS", int(c.Password.Argon2Iterations))
+	v.positiveInt("PASSWORD_ARGON2_PARALLELISM", int(c.Password.Argon2Parallelism))
+	v.atLeast("PASSWORD_ARGON2_MEMORY", int(c.Password.Argon2Memory), max(8*int(c.Password.Argon2Parallelism), 8))
+	v.atLeast("PASSWORD_ARGON2_SALT_LENGTH", int(c.Password.Argon2SaltLength), 8)
+	v.atLeast("PASSWORD_ARGON2_KEY_LENGTH", int(c.Password.Argon2KeyLength), 16)
+
+	if c.Password.MinLength < 1 || c.Password.MaxLength < c.Password.MinLength {
+		v.addf("PASSWORD_MIN_LENGTH and PASSWORD_MAX_LENGTH: need 1 <= min <= max, got %d and %d",
+			c.Password.MinLength, c.Password.MaxLength)
+	}
+
+	if c.Password.MinStrength < 0 || c.Password.MinStrength > 4 {
+		v.addf("PASSWORD_MIN_STRENGTH: must be in [0, 4], got %d", c.Password.MinStrength)
+	}
+
+	if c.Password.MaxSimilarity < 0 || c.Password.MaxSimilarity > 1 {
+		v.addf("PASSWORD_MAX_SIMILARITY: must be in [0, 1], got %g", c.Password.MaxSimilarity)
+	}
+
+	v.required("OPEN_ID_CLIENT_ID", c.OAuth.ClientID)
+
+	v.required("OTP_SECRET", c.OTP.Secret)
+	v.positive("OTP_CODE_TTL", c.OTP.CodeTTL)
+	v.positive("OTP_THROTTLE_WINDOW", c.OTP.ThrottleWindow)
+	v.positiveInt("OTP_MAX_ATTEMPTS", c.OTP.MaxAttempts)
+
+	if v.oneOf("MAIL_DRIVER", c.Mail.Driver, "log", "smtp") && c.Mail.Driver == "smtp" {
+		v.required("SMTP_HOST", c.Mail.SMTPHost)
+	}
+
+	if v.oneOf("SMS_DRIVER", c.SMS.Driver, "log", "file") && c.SMS.Driver == "file" {
+		v.required("SMS_FILE", c.SMS.File)
+	}
+
+	if c.Challenge.Enabled {
+		v.required("CHALLENGE_SECRET", c.Challenge.Secret)
+	}
+
+	if c.Challenge.Difficulty < 0 || c.Challenge.Difficulty > 32 {
+		v.addf("CHALLENGE_DIFFICULTY: must be in [0, 32], got %d", c.Challenge.Difficulty)
+	}
+
+	v.positive("CHALLENGE_TTL", c.Challenge.TTL)
+
+	if v.oneOf("CAPTCHA_PROVIDER", c.Challenge.CaptchaProvider, "", "siteverify", "static") {
+		switch c.Challenge.CaptchaProvider {
+		case "siteverify":
+			v.required("CAPTCHA_VERIFY_URL", c.Challenge.CaptchaVerifyURL)
+			v.required("CAPTCHA_SECRET", c.Challenge.CaptchaSecret)
+		case "static":
+			v.required("CAPTCHA_STATIC_TOKEN", c.Challenge.CaptchaStaticToken)
+		}
+	}
+
+	v.oneOf("DB_DRIVER", c.DB.Driver, "postgres")
+	v.oneOf("DB_LOG_LEVEL", c.DB.LogLevel, "silent", "error", "warn", "info")
+
+	if c.DB.SlowQueryThreshold < 0 {
+		v.addf("DB_SLOW_QUERY_THRESHOLD: must not be negative, got %s", c.DB.SlowQueryThreshold)
+	}
+
+	if c.DB.LogSampleRatio < 0 || c.DB.LogSampleRatio > 1 {
+		v.addf("DB_LOG_SAMPLE_RATIO: must be in [0, 1], got %g", c.DB.LogSampleRatio)
+	}
+
+	if c.DB.URL == "" {
+		v.required("DB_HOST", c.DB.Host)
+		v.required("DB_PORT", c.DB.Port)
+		v.required("DB_USER", c.DB.User)
+		v.required("DB_NAME", c.DB.Name)
+		v.oneOf("DB_SSL_MODE", c.DB.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+
+		if (c.DB.SSLCert == "") != (c.DB.SSLKey == "") {
+			v.addf("DB_SSL_CERT and DB_SSL_KEY: must be set together")
+		}
+	}
+
+	if c.DB.MaxOpenConns < 0 || c.DB.MaxIdleConns < 0 {
+		v.addf("DB_MAX_OPEN_CONNS and DB_MAX_IDLE_CONNS: must not be negative")
+	}
+
+	if c.DB.StatementTimeout < 0 || c.DB.ConnMaxLifetime < 0 || c.DB.ConnMaxIdleTime < 0 {
+		v.addf("DB_STATEMENT_TIMEOUT, DB_CONN_MAX_LIFETIME and DB_CONN_MAX_IDLE_TIME: must not be negative")
+	}
+
+	if len(c.DB.ReplicaURLs) > 0 {
+		v.positive("DB_REPLICA_CHECK_INTERVAL", c.DB.ReplicaCheckInterval)
+		v.positive("DB_REPLICA_MAX_LAG", c.DB.ReplicaMaxLag)
+	}
+
+	if c.DB.ReadYourWritesWindow < 0 {
+		v.addf("DB_READ_YOUR_WRITES_WINDOW: must not be negative")
+	}
+
+	if c.DB.ConnectAttempts < 1 {
+		v.addf("DB_CONNECT_ATTEMPTS: must be at least 1, got %d", c.DB.ConnectAttempts)
+	}
+
+	v.positive("DB_CONNECT_BACKOFF", c.DB.ConnectBackoff)
+
+	if c.DB.ConnectMaxBackoff < c.DB.ConnectBackoff {
+		v.addf("DB_CONNECT_MAX_BACKOFF: must not be less than DB_CONNECT_BACKOFF")
+	}
+
+	v.required("PORT", c.HTTP.Port)
+	v.positive("READINESS_TIMEOUT", c.HTTP.ReadinessTimeout)
+
+	for _, proxy := range c.HTTP.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil {
+			v.addf("TRUSTED_PROXIES: %q is not in CIDR notation", proxy)
+		}
+	}
+
+	if c.HTTP.AdminPort != "" && c.HTTP.AdminPort == c.HTTP.Port {
+		v.addf("ADMIN_PORT: must differ from PORT")
+	}
+
+	if c.HTTP.ShutdownDrainDelay < 0 {
+		v.addf("SHUTDOWN_DRAIN_DELAY: must not be negative")
+	}
+
+	if c.App.IsProduction() {
+		if c.Mail.Driver == "log" {
+			v.addf("MAIL_DRIVER: the log driver is not allowed in production")
+		}
+
+		if c.SMS.Driver == "log" {
+			v.addf("SMS_DRIVER: the log driver is not allowed in production")
+		}
+
+		if c.Challenge.CaptchaProvider == "static" {
+			v.addf("CAPTCHA_PROVIDER: the static provider is not allowed in production")
+		}
+
+		if c.Auth.AllowTokenMint {
+			v.addf("AUTH_ALLOW_TOKEN_MINT: token minting is not allowed in production")
+		}
+	}
+
+	if len(v.errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(v.errs...))
+	}
+
+	return nil
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) addf(format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf(format, args...))
+}
+
+func (v *validator) required(key, value string) {
+	if value == "" {
+		v.addf("%s: required", key)
+	}
+}
+
+func (v *validator) positive(key string, value time.Duration) {
+	if value <= 0 {
+		v.addf("%s: must be positive, got %s", key, value)
+	}
+}
+
+func (v *validator) positiveInt(key string, value int) {
+	if value <= 0 {
+		v.addf("%s: must be positive, got %d", key, value)
+	}
+}
+
+func (v *validator) atLeast(key string, value, minimum int) {
+	if value < minimum {
+		v.addf("%s: must be at least %d, got %d", key, minimum, value)
+	}
+}
+
+// oneOf reports whether value is allowed, adding an error otherwise.
+func (v *validator) oneOf(key, value string, allowed ...string) bool {
+	if slices.Contains(allowed, value) {
+		return true
+	}
+
+	v.addf("%s: must be one of %q, got %q", key, allowed, value)
+
+	return false
+}
@@ -0,0 +1,67 @@
+package requests
+
+import (
+	"time"
+
+	userRequests "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/audit"
+	"github.com/google/uuid"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+type FilterRequest struct {
+	ActorID    string `query:"actorId" example:"0196a7f2-8a3c-7c1e-9a42-7b0d2c1e4f55"`
+	Action     string `query:"action" example:"user.ban"`
+	TargetType string `query:"targetType" example:"user"`
+	TargetID   string `query:"targetId"`
+	From       string `query:"from" example:"2025-01-01T00:00:00Z"`
+	To         string `query:"to" example:"2025-02-01T00:00:00Z"`
+}
+
+func (fr FilterRequest) Validate() error {
+	return validation.ValidateStruct(&fr,
+		validation.Field(&fr.ActorID, is.UUID),
+		validation.Field(&fr.From, validation.Date(time.RFC3339)),
+		validation.Field(&fr.To, validation.Date(time.RFC3339)),
+	)
+}
+
+// Filter converts the request into a repository filter. It must be called after Validate.
+func (fr FilterRequest) Filter() models.EntryFilter {
+	filter := models.EntryFilter{
+		Action:     fr.Action,
+		TargetType: fr.TargetType,
+		TargetID:   fr.TargetID,
+	}
+
+	if actorID, err := uuid.Parse(fr.ActorID); err == nil {
+		filter.ActorID = &actorID
+	}
+	if from, err := time.Parse(time.RFC3339, fr.From); err == nil {
+		filter.From = &from
+	}
+	if to, err := time.Parse(time.RFC3339, fr.To); err == nil {
+		filter.To = &to
+	}
+
+	return filter
+}
+
+type ListRequest struct {
+	FilterRequest
+	userRequests.PageRequest
+}
+
+func NewListRequest() ListRequest {
+	return ListRequest{PageRequest: userRequests.NewPageRequest()}
+}
+
+func (lr ListRequest) Validate() error {
+	if err := lr.FilterRequest.Validate(); err != nil {
+		return err
+	}
+
+	return lr.PageRequest.Validate()
+}
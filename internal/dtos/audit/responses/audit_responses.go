@@ -0,0 +1,62 @@
+package responses
+
+import (
+	"encoding/json"
+	"time"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/audit"
+	"github.com/google/uuid"
+)
+
+type Entry struct {
+	Seq        int64           `json:"seq"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	ActorType  string          `json:"actorType" example:"user"`
+	ActorID    *uuid.UUID      `json:"actorId,omitempty"`
+	Action     string          `json:"action" example:"user.ban"`
+	TargetType string          `json:"targetType" example:"user"`
+	TargetID   string          `json:"targetId"`
+	Diff       json.RawMessage `json:"diff" swaggertype:"object"`
+	TraceID    string          `json:"traceId,omitempty"`
+	IP         string          `json:"ip,omitempty"`
+	Hash       string          `json:"hash"`
+}
+
+type EntriesResponse struct {
+	Items    []Entry `json:"items"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"pageSize"`
+	Total    int64   `json:"total"`
+}
+
+func NewEntriesResponse(entries []models.Entry, page, pageSize int, total int64) *EntriesResponse {
+	items := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, Entry{
+			Seq:        entry.Seq,
+			CreatedAt:  entry.CreatedAt,
+			ActorType:  entry.ActorType,
+			ActorID:    entry.ActorID,
+			Action:     entry.Action,
+			TargetType: entry.TargetType,
+			TargetID:   entry.TargetID,
+			Diff:       json.RawMessage(entry.Diff),
+			TraceID:    entry.TraceID,
+			IP:         entry.IP,
+			Hash:       entry.Hash,
+		})
+	}
+
+	return &EntriesResponse{
+		Items:    items,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}
+}
+
+type VerifyResponse struct {
+	Valid    bool   `json:"valid"`
+	Checked  int64  `json:"checked"`
+	BrokenAt *int64 `json:"brokenAt,omitempty"`
+}
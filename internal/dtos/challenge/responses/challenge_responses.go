@@ -0,0 +1,26 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/challenge"
+)
+
+type ChallengeResponse struct {
+	// Send back in the X-Challenge header, with the solution in X-Challenge-Solution.
+	Challenge string `json:"challenge"`
+
+	// Number of leading zero bits required in SHA-256(challenge + ":" + solution).
+	Difficulty int       `json:"difficulty" example:"18"`
+	Algorithm  string    `json:"algorithm" example:"sha256"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+func NewChallengeResponse(c challenge.Challenge) *ChallengeResponse {
+	return &ChallengeResponse{
+		Challenge:  c.Token,
+		Difficulty: c.Difficulty,
+		Algorithm:  "sha256",
+		ExpiresAt:  c.ExpiresAt,
+	}
+}
@@ -0,0 +1,45 @@
+package responses
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Problem describes a failed request as problem details (RFC 7807). Code is stable for clients to
+// branch on, and TraceID is the ID to quote when reporting the problem.
+type Problem struct {
+	Type     string `json:"type" example:"/problems/user_not_found"`
+	Title    string `json:"title" example:"User not found"`
+	Status   int    `json:"status" example:"404"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty" example:"/api/external/v1/admin/users/4b3c2a1d-0000-0000-0000-000000000000/ban"`
+	Code     string `json:"code" example:"user_not_found"`
+	TraceID  string `json:"traceId,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+
+	// Errors maps the invalid fields of a request to what is wrong with them.
+	Errors map[string]string `json:"errors,omitempty"`
+
+	// Extensions are added as members of their own, such as the violations of the password policy.
+	Extensions map[string]any `json:"-"`
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+
+	data, err := json.Marshal(problem(p))
+	if err != nil {
+		return nil, fmt.Errorf("marshal problem: %w", err)
+	}
+
+	if len(p.Extensions) == 0 {
+		return data, nil
+	}
+
+	extensions, err := json.Marshal(p.Extensions)
+	if err != nil {
+		return nil, fmt.Errorf("marshal problem extensions: %w", err)
+	}
+
+	// Both are JSON objects: splice the extension members into the problem.
+	return append(append(data[:len(data)-1], ','), extensions[1:]...), nil
+}
@@ -4,11 +4,6 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-type Error struct {
-	Code  int    `json:"code"`
-	Error string `json:"error"`
-}
-
 type Data struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
@@ -24,10 +19,3 @@ func MessageResponse(c echo.Context, statusCode int, message string) error {
 		Message: message,
 	})
 }
-
-func ErrorResponse(c echo.Context, statusCode int, message string) error {
-	return Response(c, statusCode, Error{
-		Code:  statusCode,
-		Error: message,
-	})
-}
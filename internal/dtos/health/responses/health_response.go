@@ -0,0 +1,56 @@
+package responses
+
+import (
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/health"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+	StatusDraining    = "draining"
+	StatusFailed      = "failed"
+)
+
+type HealthResponse struct {
+	// One of: "ok", "unavailable", "draining".
+	Status string                `json:"status" example:"ok"`
+	Checks []CheckResultResponse `json:"checks,omitempty"`
+}
+
+// CheckResultResponse is public, so failure details are logged rather than returned.
+type CheckResultResponse struct {
+	Name string `json:"name" example:"database"`
+
+	// One of: "ok", "failed".
+	Status     string `json:"status" example:"ok"`
+	Optional   bool   `json:"optional,omitempty"`
+	DurationMs int64  `json:"durationMs" example:"3"`
+}
+
+func NewHealthResponse(report health.Report) *HealthResponse {
+	response := &HealthResponse{Status: StatusOK}
+
+	switch {
+	case report.Draining:
+		response.Status = StatusDraining
+	case !report.Ready:
+		response.Status = StatusUnavailable
+	}
+
+	for _, result := range report.Results {
+		check := CheckResultResponse{
+			Name:       result.Name,
+			Status:     StatusOK,
+			Optional:   result.Optional,
+			DurationMs: result.Duration.Milliseconds(),
+		}
+
+		if result.Err != nil {
+			check.Status = StatusFailed
+		}
+
+		response.Checks = append(response.Checks, check)
+	}
+
+	return response
+}
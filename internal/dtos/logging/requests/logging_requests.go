@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type SetLevelRequest struct {
+	// Import path of a package whose level is overridden. The default level if empty.
+	Package string `json:"package" example:"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db"`
+	// DEBUG, INFO, WARN or ERROR, optionally with an offset such as INFO+2. Empty removes the
+	// override of the package.
+	Level string `json:"level" example:"DEBUG"`
+}
+
+func (sr SetLevelRequest) Validate() error {
+	return validation.ValidateStruct(&sr,
+		validation.Field(&sr.Level, validation.When(sr.Package == "", validation.Required)),
+	)
+}
+
+type DebugTokenRequest struct {
+	// How long the token is valid, as a Go duration.
+	TTL string `json:"ttl" validate:"required" example:"30m"`
+}
+
+func (dr DebugTokenRequest) Validate() error {
+	return validation.ValidateStruct(&dr,
+		validation.Field(&dr.TTL, validation.Required, validation.By(func(value any) error {
+			_, err := time.ParseDuration(value.(string))
+
+			return err
+		})),
+	)
+}
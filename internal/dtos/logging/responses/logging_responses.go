@@ -0,0 +1,29 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/logging"
+)
+
+type LevelsResponse struct {
+	// Level of packages without override.
+	Level string `json:"level" example:"INFO"`
+	// Levels by package import path.
+	Overrides map[string]string `json:"overrides"`
+}
+
+func NewLevelsResponse(levels logging.Levels) *LevelsResponse {
+	overrides := make(map[string]string, len(levels.Overrides))
+	for packagePath, level := range levels.Overrides {
+		overrides[packagePath] = level.String()
+	}
+
+	return &LevelsResponse{Level: levels.Level.String(), Overrides: overrides}
+}
+
+type DebugTokenResponse struct {
+	// Send in the X-Debug-Token header to log the request at DEBUG level.
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
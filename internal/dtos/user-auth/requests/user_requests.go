@@ -5,10 +5,6 @@ import (
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
-const (
-	minPathLength = 8
-)
-
 type BasicAuth struct {
 	Email    string `json:"email" validate:"required" example:"john.doe@example.com"`
 	Password string `json:"password" validate:"required" example:"11111111"`
@@ -17,7 +13,7 @@ type BasicAuth struct {
 func (ba BasicAuth) Validate() error {
 	return validation.ValidateStruct(&ba,
 		validation.Field(&ba.Email, is.Email),
-		validation.Field(&ba.Password, validation.Length(minPathLength, 0)),
+		validation.Field(&ba.Password, validation.Required),
 	)
 }
 
@@ -54,3 +50,100 @@ func (oar OAuthRequest) Validate() error {
 type RefreshRequest struct {
 	Token string `json:"token" validate:"required" example:"refresh_token"`
 }
+
+const (
+	DefaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type PageRequest struct {
+	Page     int `query:"page" example:"1"`
+	PageSize int `query:"pageSize" example:"20"`
+}
+
+func NewPageRequest() PageRequest {
+	return PageRequest{Page: 1, PageSize: DefaultPageSize}
+}
+
+func (pr PageRequest) Validate() error {
+	return validation.ValidateStruct(&pr,
+		validation.Field(&pr.Page, validation.Min(1)),
+		validation.Field(&pr.PageSize, validation.Min(1), validation.Max(maxPageSize)),
+	)
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" validate:"required" example:"MODERATOR"`
+}
+
+func (cr ChangeRoleRequest) Validate() error {
+	return validation.ValidateStruct(&cr,
+		validation.Field(&cr.Role, validation.Required),
+	)
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" validate:"required" example:"11111111"`
+	NewPassword     string `json:"newPassword" validate:"required" example:"correct horse battery staple"`
+}
+
+func (cp ChangePasswordRequest) Validate() error {
+	return validation.ValidateStruct(&cp,
+		validation.Field(&cp.CurrentPassword, validation.Required),
+		validation.Field(&cp.NewPassword, validation.Required),
+	)
+}
+
+type EmailLinkRequest struct {
+	Email    string `json:"email" validate:"required" example:"john.doe@example.com"`
+	DeviceID string `json:"deviceId" validate:"required" example:"8f14e45f-ceea-467f-a0e6-1f2a3b4c5d6e"`
+}
+
+func (el EmailLinkRequest) Validate() error {
+	return validation.ValidateStruct(&el,
+		validation.Field(&el.Email, validation.Required, is.Email),
+		validation.Field(&el.DeviceID, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// EmailLinkVerifyRequest carries either the token from the emailed link, or the email address and the emailed code.
+type EmailLinkVerifyRequest struct {
+	Email    string `json:"email" example:"john.doe@example.com"`
+	Code     string `json:"code" example:"123456"`
+	Token    string `json:"token" example:"link_token"`
+	DeviceID string `json:"deviceId" validate:"required" example:"8f14e45f-ceea-467f-a0e6-1f2a3b4c5d6e"`
+}
+
+func (elv EmailLinkVerifyRequest) Validate() error {
+	return validation.ValidateStruct(&elv,
+		validation.Field(&elv.Email, validation.When(elv.Token == "", validation.Required, is.Email)),
+		validation.Field(&elv.Code, validation.When(elv.Token == "", validation.Required, is.Digit)),
+		validation.Field(&elv.DeviceID, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+type PhoneRequest struct {
+	Phone    string `json:"phone" validate:"required" example:"+84912345678"`
+	DeviceID string `json:"deviceId" validate:"required" example:"8f14e45f-ceea-467f-a0e6-1f2a3b4c5d6e"`
+}
+
+func (pr PhoneRequest) Validate() error {
+	return validation.ValidateStruct(&pr,
+		validation.Field(&pr.Phone, validation.Required, validation.Length(1, 32)),
+		validation.Field(&pr.DeviceID, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+type PhoneVerifyRequest struct {
+	Phone    string `json:"phone" validate:"required" example:"+84912345678"`
+	Code     string `json:"code" validate:"required" example:"123456"`
+	DeviceID string `json:"deviceId" validate:"required" example:"8f14e45f-ceea-467f-a0e6-1f2a3b4c5d6e"`
+}
+
+func (pv PhoneVerifyRequest) Validate() error {
+	return validation.ValidateStruct(&pv,
+		validation.Field(&pv.Phone, validation.Required, validation.Length(1, 32)),
+		validation.Field(&pv.Code, validation.Required, is.Digit),
+		validation.Field(&pv.DeviceID, validation.Required, validation.Length(1, 255)),
+	)
+}
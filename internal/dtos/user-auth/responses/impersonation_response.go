@@ -0,0 +1,14 @@
+package responses
+
+// ImpersonationResponse carries an access token for acting as another user. It cannot be refreshed.
+type ImpersonationResponse struct {
+	AccessToken string `json:"accessToken"`
+	Exp         int64  `json:"exp"`
+}
+
+func NewImpersonationResponse(token string, exp int64) *ImpersonationResponse {
+	return &ImpersonationResponse{
+		AccessToken: token,
+		Exp:         exp,
+	}
+}
@@ -0,0 +1,47 @@
+package responses
+
+import (
+	"time"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/google/uuid"
+)
+
+type LoginEvent struct {
+	ID            uuid.UUID `json:"id"`
+	Method        string    `json:"method" example:"password"`
+	Outcome       string    `json:"outcome" example:"success"`
+	FailureReason string    `json:"failureReason,omitempty" example:"invalid_password"`
+	IP            string    `json:"ip" example:"203.0.113.7"`
+	UserAgent     string    `json:"userAgent"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type LoginEventsResponse struct {
+	Items    []LoginEvent `json:"items"`
+	Page     int          `json:"page"`
+	PageSize int          `json:"pageSize"`
+	Total    int64        `json:"total"`
+}
+
+func NewLoginEventsResponse(events []models.LoginEvent, page, pageSize int, total int64) *LoginEventsResponse {
+	items := make([]LoginEvent, 0, len(events))
+	for _, event := range events {
+		items = append(items, LoginEvent{
+			ID:            event.ID,
+			Method:        string(event.Method),
+			Outcome:       string(event.Outcome),
+			FailureReason: event.FailureReason,
+			IP:            event.IP,
+			UserAgent:     event.UserAgent,
+			CreatedAt:     event.CreatedAt,
+		})
+	}
+
+	return &LoginEventsResponse{
+		Items:    items,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}
+}
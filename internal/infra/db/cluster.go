@@ -0,0 +1,203 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+// replicaStatusQuery reports whether a replica is streaming WAL from the primary and how far it is
+// behind in seconds. A streaming replica that has replayed everything it received is not lagging,
+// even if the primary has been idle for a while. A replica whose WAL receiver is down falls behind
+// without noticing, so it is not streaming. Seeing the receiver status needs the pg_read_all_stats role.
+const replicaStatusQuery = `SELECT
+	NOT pg_is_in_recovery() OR EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE status = 'streaming') AS streaming,
+	CASE
+		WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END AS lag_seconds`
+
+type replicaStatus struct {
+	Streaming  bool
+	LagSeconds float64
+}
+
+// ClusterConfig configures replica routing.
+type ClusterConfig struct {
+	// CheckInterval is how often replicas are pinged and their lag measured.
+	CheckInterval time.Duration
+
+	// MaxLag is the highest lag at which a replica still serves reads.
+	MaxLag time.Duration
+
+	// PinWindow is how long reads go to the primary after a write in the same session.
+	PinWindow time.Duration
+
+	// Registerer receives the replica metrics. They are not registered if nil.
+	Registerer prometheus.Registerer
+}
+
+// Cluster routes reads to healthy replicas and writes to the primary. Transactions must use
+// [Cluster.Transaction] or [Cluster.Writer]. Without replicas, every query goes to the primary.
+type Cluster struct {
+	now      func() time.Time
+	config   ClusterConfig
+	primary  *gorm.DB
+	replicas []*replica
+	next     atomic.Uint64
+
+	lag     *prometheus.GaugeVec
+	healthy *prometheus.GaugeVec
+}
+
+type replica struct {
+	name    string
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// NewCluster creates a cluster. Replicas serve reads only after their first successful check, see
+// [Cluster.Run].
+func NewCluster(now func() time.Time, config ClusterConfig, primary *gorm.DB, replicas ...*gorm.DB) *Cluster {
+	factory := promauto.With(config.Registerer)
+
+	c := &Cluster{
+		now:     now,
+		config:  config,
+		primary: primary,
+		lag: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "db_replica_lag_seconds",
+			Help: "Replication lag of a database read replica.",
+		}, []string{"replica"}),
+		healthy: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "db_replica_healthy",
+			Help: "Whether a database read replica serves reads (1) or not (0).",
+		}, []string{"replica"}),
+	}
+
+	for i, db := range replicas {
+		c.replicas = append(c.replicas, &replica{name: "replica-" + strconv.Itoa(i), db: db})
+	}
+
+	return c
+}
+
+// Primary returns the primary database for repositories that never read from replicas.
+func (c *Cluster) Primary() *gorm.DB {
+	return c.primary
+}
+
+// Writer returns the primary and pins the session of ctx to it for the read-your-writes window.
+func (c *Cluster) Writer(ctx context.Context) *gorm.DB {
+	if session, ok := sessionFrom(ctx); ok {
+		session.markWrite(c.now())
+	}
+
+	return Conn(ctx, c.primary)
+}
+
+// Transaction runs fn in a transaction on the primary, see [Transaction]. Reads and writes made
+// through the cluster with the context passed to fn join it.
+func (c *Cluster) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if session, ok := sessionFrom(ctx); ok {
+		session.markWrite(c.now())
+	}
+
+	return Transaction(ctx, c.primary, fn)
+}
+
+// Reader returns a healthy replica in round-robin order, or the primary if the session of ctx wrote
+// recently or no replica is healthy. Inside a transaction, it returns the transaction.
+func (c *Cluster) Reader(ctx context.Context) *gorm.DB {
+	if tx, ok := txFrom(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+
+	if session, ok := sessionFrom(ctx); ok && c.now().Sub(session.LastWrite()) < c.config.PinWindow {
+		return c.primary.WithContext(ctx)
+	}
+
+	for range c.replicas {
+		replica := c.replicas[c.next.Add(1)%uint64(len(c.replicas))]
+		if replica.healthy.Load() {
+			return replica.db.WithContext(ctx)
+		}
+	}
+
+	return c.primary.WithContext(ctx)
+}
+
+// Run checks the replicas every CheckInterval until ctx is done.
+func (c *Cluster) Run(ctx context.Context) {
+	if len(c.replicas) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		c.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Cluster) check(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, replica := range c.replicas {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			healthy := c.checkReplica(ctx, replica)
+			if replica.healthy.Swap(healthy) != healthy {
+				slog.WarnContext(ctx, "Database replica health changed", "replica", replica.name, "healthy", healthy)
+			}
+
+			gauge := c.healthy.WithLabelValues(replica.name)
+			if healthy {
+				gauge.Set(1)
+			} else {
+				gauge.Set(0)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (c *Cluster) checkReplica(ctx context.Context, replica *replica) bool {
+	ctx, cancel := context.WithTimeout(ctx, c.config.CheckInterval)
+	defer cancel()
+
+	var status replicaStatus
+	if err := replica.db.WithContext(ctx).Raw(replicaStatusQuery).Scan(&status).Error; err != nil {
+		slog.DebugContext(ctx, "Check database replica", "replica", replica.name, "err", err.Error())
+
+		return false
+	}
+
+	c.lag.WithLabelValues(replica.name).Set(status.LagSeconds)
+
+	if !status.Streaming {
+		slog.DebugContext(ctx, "Database replica is not streaming from the primary", "replica", replica.name)
+
+		return false
+	}
+
+	return time.Duration(status.LagSeconds*float64(time.Second)) <= c.config.MaxLag
+}
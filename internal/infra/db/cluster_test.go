@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// openLazy returns a database that connects on first use, which the routing tests never do.
+func openLazy(t *testing.T, host string) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.Open("host="+host), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	return db
+}
+
+// servedBy reports whether a session returned by the cluster uses the connection pool of db.
+func servedBy(session, db *gorm.DB) bool {
+	return session.Statement.ConnPool == db.ConnPool
+}
+
+func newTestCluster(t *testing.T, now *time.Time, replicaCount int) (*Cluster, *gorm.DB, []*gorm.DB) {
+	t.Helper()
+
+	primary := openLazy(t, "primary")
+
+	var replicas []*gorm.DB
+	for range replicaCount {
+		replicas = append(replicas, openLazy(t, "replica"))
+	}
+
+	cluster := NewCluster(func() time.Time { return *now }, ClusterConfig{PinWindow: 5 * time.Second}, primary, replicas...)
+
+	return cluster, primary, replicas
+}
+
+func TestClusterRouting(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 5, 9, 10, 0, 0, 0, time.UTC)
+	cluster, primary, replicas := newTestCluster(t, &now, 2)
+	ctx := context.Background()
+
+	assert.True(t, servedBy(cluster.Reader(ctx), primary), "replicas serve reads only after a successful check")
+
+	for _, replica := range cluster.replicas {
+		replica.healthy.Store(true)
+	}
+
+	first, second := cluster.Reader(ctx), cluster.Reader(ctx)
+	assert.True(t, servedBy(first, replicas[0]) && servedBy(second, replicas[1]) ||
+		servedBy(first, replicas[1]) && servedBy(second, replicas[0]), "reads are spread over the replicas")
+
+	cluster.replicas[0].healthy.Store(false)
+	for range 3 {
+		assert.True(t, servedBy(cluster.Reader(ctx), replicas[1]), "unhealthy replicas are skipped")
+	}
+
+	assert.True(t, servedBy(cluster.Writer(ctx), primary))
+}
+
+func TestClusterReadYourWrites(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 5, 9, 10, 0, 0, 0, time.UTC)
+	cluster, primary, replicas := newTestCluster(t, &now, 1)
+	cluster.replicas[0].healthy.Store(true)
+
+	ctx, session := WithSession(context.Background(), time.Time{})
+	other := context.Background()
+
+	assert.True(t, servedBy(cluster.Reader(ctx), replicas[0]))
+
+	cluster.Writer(ctx)
+	assert.Equal(t, now, session.LastWrite())
+
+	assert.True(t, servedBy(cluster.Reader(ctx), primary), "the writing session reads from the primary")
+	assert.True(t, servedBy(cluster.Reader(other), replicas[0]), "other sessions are not pinned")
+
+	now = now.Add(5 * time.Second)
+	assert.True(t, servedBy(cluster.Reader(ctx), replicas[0]), "the pin ends after the window")
+}
@@ -1,26 +1,141 @@
 package db
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func NewGormDB(cfg config.DBConfig) (*gorm.DB, error) {
+// NewGormDB opens the database and waits until it answers, retrying with exponential backoff as
+// configured. It gives up early if ctx is done.
+func NewGormDB(ctx context.Context, cfg config.DBConfig) (*gorm.DB, error) {
+	db, err := open(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get sql db: %w", err)
+	}
+
+	if err := ping(ctx, sqlDB, cfg); err != nil {
+		sqlDB.Close()
+
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// NewReplicaDB opens the read replica at url with the driver and pool settings of cfg. It does not
+// wait for the replica, [Cluster.Run] checks it in the background.
+func NewReplicaDB(cfg config.DBConfig, url string) (*gorm.DB, error) {
+	cfg.URL = url
+
+	return open(cfg)
+}
+
+func open(cfg config.DBConfig) (*gorm.DB, error) {
+	if cfg.Driver != "postgres" {
+		return nil, fmt.Errorf("unsupported db driver %q", cfg.Driver)
+	}
+
 	db, err := gorm.Open(postgres.Open(dsn(cfg)), &gorm.Config{
-		Logger: newLoggerAdapter(),
+		Logger:               newLoggerAdapter(loggerConfig(cfg)),
+		DisableAutomaticPing: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("open db connection: %w", err)
 	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get sql db: %w", err)
+	}
+
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
 	return db, nil
 }
 
+func ping(ctx context.Context, sqlDB *sql.DB, cfg config.DBConfig) error {
+	delay := cfg.ConnectBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := sqlDB.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= cfg.ConnectAttempts {
+			return fmt.Errorf("ping db after %d attempts: %w", attempt, err)
+		}
+
+		slog.WarnContext(ctx, "Could not reach database, retrying",
+			"attempt", attempt,
+			"delay", delay.String(),
+			"err", err.Error(),
+		)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("ping db: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay = min(2*delay, cfg.ConnectMaxBackoff)
+	}
+}
+
 func dsn(c config.DBConfig) string {
-	return fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Ho_Chi_Minh",
-		c.Host, c.User, c.Password, c.Name, c.Port,
-	)
+	if c.URL != "" {
+		return c.URL
+	}
+
+	settings := [][2]string{
+		{"host", c.Host},
+		{"port", c.Port},
+		{"user", c.User},
+		{"password", c.Password},
+		{"dbname", c.Name},
+		{"sslmode", c.SSLMode},
+		{"sslrootcert", c.SSLRootCert},
+		{"sslcert", c.SSLCert},
+		{"sslkey", c.SSLKey},
+		{"TimeZone", c.TimeZone},
+	}
+
+	if c.StatementTimeout > 0 {
+		settings = append(settings, [2]string{"statement_timeout", strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10)})
+	}
+
+	parts := make([]string, 0, len(settings))
+	for _, setting := range settings {
+		if setting[1] != "" {
+			parts = append(parts, setting[0]+"="+quote(setting[1]))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// quote escapes a value of a keyword/value connection string.
+func quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
 }
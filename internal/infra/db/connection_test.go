@@ -0,0 +1,41 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDSN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  config.DBConfig
+		want string
+	}{
+		{
+			name: "settings",
+			cfg: config.DBConfig{
+				Host: "db", Port: "5432", User: "app", Password: "pa ss'word", Name: "main",
+				SSLMode: "verify-full", SSLRootCert: "/certs/ca.pem", TimeZone: "UTC", StatementTimeout: 5 * time.Second,
+			},
+			want: `host=db port=5432 user=app password='pa ss\'word' dbname=main sslmode=verify-full sslrootcert=/certs/ca.pem TimeZone=UTC statement_timeout=5000`,
+		},
+		{
+			name: "url overrides settings",
+			cfg:  config.DBConfig{Host: "db", URL: "postgres://app@replica:5432/main?sslmode=require"},
+			want: "postgres://app@replica:5432/main?sslmode=require",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, dsn(test.cfg))
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("update: %w", &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_verified_phone"})
+
+	assert.True(t, IsUniqueViolation(err, "idx_users_verified_phone"))
+	assert.False(t, IsUniqueViolation(err, "users_email_key"))
+	assert.False(t, IsUniqueViolation(&pgconn.PgError{Code: "23503", ConstraintName: "idx_users_verified_phone"}, "idx_users_verified_phone"))
+	assert.False(t, IsUniqueViolation(errors.New("duplicate key"), "idx_users_verified_phone"))
+}
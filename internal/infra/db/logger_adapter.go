@@ -2,47 +2,208 @@ package db
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"math/rand/v2"
+	"reflect"
+	"strings"
 	"time"
 
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/redact"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-var _ logger.Interface = (*LoggerAdapter)(nil)
+var tracer = otel.Tracer("github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db")
 
-type LoggerAdapter struct{}
+var (
+	_ logger.Interface  = (*LoggerAdapter)(nil)
+	_ gorm.ParamsFilter = (*LoggerAdapter)(nil)
+)
+
+// LoggerConfig configures [LoggerAdapter].
+type LoggerConfig struct {
+	// Level selects what is logged: failed queries at ERROR from [logger.Error], slow queries at WARN
+	// from [logger.Warn] and every query at DEBUG from [logger.Info].
+	Level logger.LogLevel
+
+	// SlowThreshold is the duration from which a query is slow. Zero disables slow query logs.
+	SlowThreshold time.Duration
+
+	// SampleRatio is the fraction in [0, 1] of ordinary queries logged at DEBUG.
+	SampleRatio float64
+
+	// Parameters logs the values of query parameters instead of redacting them.
+	Parameters bool
+}
+
+// LoggerAdapter logs gorm queries with slog, and traces and measures them. Missing records are not
+// errors. Queries of requests escalated with [slogx.WithDebug] are logged unless the level is
+// [logger.Silent].
+type LoggerAdapter struct {
+	config   LoggerConfig
+	random   func() float64
+	database string
+	metrics  *QueryMetrics
+}
+
+func newLoggerAdapter(config LoggerConfig) *LoggerAdapter {
+	return &LoggerAdapter{config: config, random: rand.Float64}
+}
+
+// loggerConfig returns the logger settings of cfg, which must be valid.
+func loggerConfig(cfg config.DBConfig) LoggerConfig {
+	levels := map[string]logger.LogLevel{
+		"silent": logger.Silent,
+		"error":  logger.Error,
+		"warn":   logger.Warn,
+		"info":   logger.Info,
+	}
 
-func newLoggerAdapter() *LoggerAdapter {
-	return &LoggerAdapter{}
+	return LoggerConfig{
+		Level:         levels[cfg.LogLevel],
+		SlowThreshold: cfg.SlowQueryThreshold,
+		SampleRatio:   cfg.LogSampleRatio,
+		Parameters:    cfg.LogParameters,
+	}
 }
 
-func (a *LoggerAdapter) LogMode(logger.LogLevel) logger.Interface {
-	return a
+// LogMode returns a copy of the adapter with the given level, as used by [gorm.DB.Debug].
+func (a *LoggerAdapter) LogMode(level logger.LogLevel) logger.Interface {
+	adapter := *a
+	adapter.config.Level = level
+
+	return &adapter
 }
 
 func (a *LoggerAdapter) Info(ctx context.Context, message string, args ...any) {
-	slog.InfoContext(ctx, message, "args", args)
+	if a.config.Level >= logger.Info {
+		slog.InfoContext(ctx, message, "args", args)
+	}
 }
 
 func (a *LoggerAdapter) Warn(ctx context.Context, message string, args ...any) {
-	slog.WarnContext(ctx, message, "args", args)
+	if a.config.Level >= logger.Warn {
+		slog.WarnContext(ctx, message, "args", args)
+	}
 }
 
 func (a *LoggerAdapter) Error(ctx context.Context, message string, args ...any) {
-	slog.ErrorContext(ctx, message, "args", args)
+	if a.config.Level >= logger.Error {
+		slog.ErrorContext(ctx, message, "args", args)
+	}
+}
+
+// ParamsFilter redacts the parameters that gorm inlines in the statements passed to Trace.
+func (a *LoggerAdapter) ParamsFilter(_ context.Context, sql string, params ...any) (string, []any) {
+	if a.config.Parameters {
+		return sql, params
+	}
+
+	redacted := make([]any, len(params))
+	for i, param := range params {
+		redacted[i] = redactParameter(param)
+	}
+
+	return sql, redacted
 }
 
 func (a *LoggerAdapter) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	duration := time.Since(begin)
 	sql, rowsAffected := fc()
+	operation := operation(sql)
+
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := a.config.SlowThreshold > 0 && duration >= a.config.SlowThreshold
+
+	if a.metrics != nil {
+		a.metrics.observe(a.database, operation, duration, failed, slow)
+	}
+
+	// The statement is not recorded: parameters are only redacted in logs.
+	_, span := tracer.Start(ctx, "db "+operation,
+		trace.WithTimestamp(begin),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(operation),
+		),
+	)
+
+	if failed {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "query failed")
+	}
+
+	span.End()
+
+	if a.config.Level == logger.Silent {
+		return
+	}
 
 	args := []any{
-		"begin", begin.Format(time.DateTime),
 		"sql", sql,
 		"rows_affected", rowsAffected,
+		"duration", duration,
+	}
+
+	if a.database != "" {
+		args = append(args, "database", a.database)
+	}
+
+	switch {
+	case failed && a.config.Level >= logger.Error:
+		slog.ErrorContext(ctx, "Database query failed", append(args, "err", err.Error())...)
+	case slow && a.config.Level >= logger.Warn:
+		slog.WarnContext(ctx, "Slow database query", append(args, "threshold", a.config.SlowThreshold)...)
+	case slogx.IsDebug(ctx) || a.config.Level >= logger.Info && a.random() < a.config.SampleRatio:
+		slog.DebugContext(ctx, "Database query", args...)
 	}
-	if err != nil {
-		args = append(args, "err", err.Error())
+}
+
+var (
+	timeType = reflect.TypeFor[time.Time]()
+	uuidType = reflect.TypeFor[uuid.UUID]()
+)
+
+// redactParameter keeps nil, numbers, booleans, times and UUIDs, which identify rows but are not
+// personal data, and replaces any other value with [redact.Placeholder].
+func redactParameter(param any) any {
+	value := reflect.ValueOf(param)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Invalid, reflect.Pointer, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return param
 	}
 
-	slog.DebugContext(ctx, "Trace DB query execution", args...)
+	if value.Type() == timeType || value.Type() == uuidType {
+		return param
+	}
+
+	return redact.Placeholder
+}
+
+// operation returns the SQL command of a statement, such as "select", for use as a metric label.
+func operation(sql string) string {
+	command, _, _ := strings.Cut(strings.TrimSpace(sql), " ")
+
+	switch command = strings.ToLower(command); command {
+	case "select", "insert", "update", "delete", "begin", "commit", "rollback", "savepoint", "release":
+		return command
+	default:
+		return "other"
+	}
 }
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/redact"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestOperation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		`SELECT * FROM "users" WHERE id = $1`: "select",
+		"  insert into users values ($1)":     "insert",
+		"UPDATE users SET role = $1":          "update",
+		"WITH recent AS (SELECT 1) SELECT 1":  "other",
+		"":                                    "other",
+	}
+
+	for sql, want := range tests {
+		assert.Equal(t, want, operation(sql), sql)
+	}
+}
+
+func TestRedactParameter(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	now := time.Now()
+	count := 3
+	var missing *string
+
+	tests := []struct {
+		param any
+		want  any
+	}{
+		{param: nil, want: nil},
+		{param: 42, want: 42},
+		{param: true, want: true},
+		{param: 1.5, want: 1.5},
+		{param: id, want: id},
+		{param: &now, want: &now},
+		{param: &count, want: &count},
+		{param: missing, want: missing},
+		{param: "$argon2id$v=19$m=65536", want: redact.Placeholder},
+		{param: []byte("secret"), want: redact.Placeholder},
+		{param: sql.NullString{String: "user@example.com", Valid: true}, want: redact.Placeholder},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, redactParameter(test.param), "%#v", test.param)
+	}
+}
+
+func TestLoggerAdapterTrace(t *testing.T) {
+	buffer := new(bytes.Buffer)
+
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	queryFailed := errors.New("duplicate key")
+
+	tests := map[string]struct {
+		level     logger.LogLevel
+		ratio     float64
+		ctx       context.Context
+		duration  time.Duration
+		err       error
+		wantLevel string
+	}{
+		"failed":                {level: logger.Error, err: queryFailed, wantLevel: "ERROR"},
+		"failed while silent":   {level: logger.Silent, err: queryFailed},
+		"not found":             {level: logger.Warn, err: gorm.ErrRecordNotFound},
+		"slow":                  {level: logger.Warn, duration: time.Second, wantLevel: "WARN"},
+		"slow below warn":       {level: logger.Error, duration: time.Second},
+		"ordinary below info":   {level: logger.Warn},
+		"ordinary sampled":      {level: logger.Info, ratio: 1, wantLevel: "DEBUG"},
+		"ordinary not sampled":  {level: logger.Info, ratio: 0.1},
+		"escalated request":     {level: logger.Warn, ctx: slogx.WithDebug(t.Context()), wantLevel: "DEBUG"},
+		"escalated when silent": {level: logger.Silent, ctx: slogx.WithDebug(t.Context())},
+	}
+
+	for name, test := range tests {
+		buffer.Reset()
+
+		adapter := newLoggerAdapter(LoggerConfig{Level: test.level, SlowThreshold: 100 * time.Millisecond, SampleRatio: test.ratio})
+		adapter.random = func() float64 { return 0.5 }
+
+		ctx := test.ctx
+		if ctx == nil {
+			ctx = t.Context()
+		}
+
+		adapter.Trace(ctx, time.Now().Add(-test.duration), func() (string, int64) {
+			return `SELECT * FROM "users" WHERE "email" = '[REDACTED]'`, 0
+		}, test.err)
+
+		if test.wantLevel == "" {
+			assert.Empty(t, buffer.String(), name)
+
+			continue
+		}
+
+		var record struct {
+			Level string `json:"level"`
+		}
+
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &record), name)
+		assert.Equal(t, test.wantLevel, record.Level, name)
+	}
+}
+
+func TestLoggerAdapterLogMode(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLoggerAdapter(LoggerConfig{Level: logger.Warn})
+
+	debug, ok := adapter.LogMode(logger.Info).(*LoggerAdapter)
+	require.True(t, ok)
+
+	assert.Equal(t, logger.Info, debug.config.Level)
+	assert.Equal(t, logger.Warn, adapter.config.Level)
+}
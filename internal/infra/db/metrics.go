@@ -0,0 +1,83 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+// QueryMetrics measures the queries and connection pools of databases.
+type QueryMetrics struct {
+	registerer prometheus.Registerer
+	duration   *prometheus.HistogramVec
+	errors     *prometheus.CounterVec
+	slow       *prometheus.CounterVec
+}
+
+// NewQueryMetrics creates the metrics and registers them with registerer, unless it is nil.
+func NewQueryMetrics(registerer prometheus.Registerer) *QueryMetrics {
+	factory := promauto.With(registerer)
+
+	return &QueryMetrics{
+		registerer: registerer,
+		duration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of database queries by database and operation.",
+			Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+		}, []string{"database", "operation"}),
+		errors: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_query_errors_total",
+			Help: "Failed database queries by database and operation. Missing records are not counted.",
+		}, []string{"database", "operation"}),
+		slow: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_slow_queries_total",
+			Help: "Database queries slower than the slow query threshold by database and operation.",
+		}, []string{"database", "operation"}),
+	}
+}
+
+// Instrument measures the queries of db and exports its pool statistics under the name database,
+// such as "primary".
+func (m *QueryMetrics) Instrument(db *gorm.DB, database string) error {
+	adapter, ok := db.Logger.(*LoggerAdapter)
+	if !ok {
+		return errors.New("db logger is not a LoggerAdapter")
+	}
+
+	instrumented := *adapter
+	instrumented.database = database
+	instrumented.metrics = m
+	db.Logger = &instrumented
+
+	if m.registerer == nil {
+		return nil
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("get sql db: %w", err)
+	}
+
+	if err := m.registerer.Register(collectors.NewDBStatsCollector(sqlDB, database)); err != nil {
+		return fmt.Errorf("register pool metrics: %w", err)
+	}
+
+	return nil
+}
+
+func (m *QueryMetrics) observe(database, operation string, duration time.Duration, failed, slow bool) {
+	m.duration.WithLabelValues(database, operation).Observe(duration.Seconds())
+
+	if failed {
+		m.errors.WithLabelValues(database, operation).Inc()
+	}
+
+	if slow {
+		m.slow.WithLabelValues(database, operation).Inc()
+	}
+}
@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/migrations"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// Migrator applies the embedded SQL migrations.
+type Migrator struct {
+	db       *sql.DB
+	locker   lock.SessionLocker
+	provider *goose.Provider
+}
+
+// NewMigrator creates a migrator that holds a Postgres advisory lock while it changes the schema, so
+// that replicas starting at the same time apply migrations one after another. Waiting for the lock
+// times out after 5 minutes.
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("new session locker: %w", err)
+	}
+
+	return newMigrator(db, goose.DialectPostgres, migrations.EmbedMigrations, locker)
+}
+
+func newMigrator(db *sql.DB, dialect goose.Dialect, fsys fs.FS, locker lock.SessionLocker) (*Migrator, error) {
+	// The migrator takes the lock itself, so that Redo holds it across both of its steps.
+	provider, err := goose.NewProvider(dialect, db, fsys)
+	if err != nil {
+		return nil, fmt.Errorf("new goose provider: %w", err)
+	}
+
+	return &Migrator{db: db, locker: locker, provider: provider}, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func() error {
+		results, err := m.provider.Up(ctx)
+		logResults(ctx, results)
+		if err != nil {
+			return fmt.Errorf("apply migrations: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Down rolls back the latest applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.locked(ctx, func() error {
+		return m.down(ctx)
+	})
+}
+
+// Redo rolls back the latest applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) error {
+	return m.locked(ctx, func() error {
+		if err := m.down(ctx); err != nil {
+			return err
+		}
+
+		result, err := m.provider.UpByOne(ctx)
+		logResults(ctx, []*goose.MigrationResult{result})
+		if err != nil {
+			return fmt.Errorf("apply migration: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (m *Migrator) down(ctx context.Context) error {
+	result, err := m.provider.Down(ctx)
+	logResults(ctx, []*goose.MigrationResult{result})
+	if err != nil {
+		return fmt.Errorf("roll back migration: %w", err)
+	}
+
+	return nil
+}
+
+// locked calls fn while holding the migration lock on a dedicated connection.
+func (m *Migrator) locked(ctx context.Context, fn func() error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get lock connection: %w", err)
+	}
+	defer conn.Close()
+
+	if err := m.locker.SessionLock(ctx, conn); err != nil {
+		return fmt.Errorf("lock migrations: %w", err)
+	}
+
+	defer func() {
+		// Release the lock even if ctx was canceled.
+		if errUnlock := m.locker.SessionUnlock(context.WithoutCancel(ctx), conn); errUnlock != nil {
+			err = errors.Join(err, fmt.Errorf("unlock migrations: %w", errUnlock))
+		}
+	}()
+
+	return fn()
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Status lists every known migration with its state.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get migration status: %w", err)
+	}
+
+	result := make([]MigrationStatus, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, MigrationStatus{
+			Version:   status.Source.Version,
+			Name:      status.Source.Path,
+			Applied:   status.State == goose.StateApplied,
+			AppliedAt: status.AppliedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// Version returns the version of the latest applied migration.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	version, err := m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get db version: %w", err)
+	}
+
+	return version, nil
+}
+
+// LatestVersion returns the version of the newest embedded migration.
+func (m *Migrator) LatestVersion() int64 {
+	sources := m.provider.ListSources()
+	if len(sources) == 0 {
+		return 0
+	}
+
+	return sources[len(sources)-1].Version
+}
+
+func logResults(ctx context.Context, results []*goose.MigrationResult) {
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		attrs := []any{
+			"version", result.Source.Version,
+			"name", result.Source.Path,
+			"direction", result.Direction,
+			"duration", result.Duration,
+		}
+
+		if result.Error != nil {
+			slog.ErrorContext(ctx, "Migration failed", append(attrs, "err", result.Error.Error())...)
+		} else {
+			slog.InfoContext(ctx, "Migration applied", attrs...)
+		}
+	}
+}
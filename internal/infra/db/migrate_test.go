@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+// recordingLocker records lock calls instead of taking a database lock.
+type recordingLocker struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *recordingLocker) SessionLock(context.Context, *sql.Conn) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls = append(l.calls, "lock")
+
+	return nil
+}
+
+func (l *recordingLocker) SessionUnlock(context.Context, *sql.Conn) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls = append(l.calls, "unlock")
+
+	return nil
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, *sql.DB, *recordingLocker) {
+	t.Helper()
+
+	// A file, since every connection to an in-memory database opens a new one
+	sqlDB, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrate.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	fsys := fstest.MapFS{
+		"1_players.sql": {Data: []byte("-- +goose Up\nCREATE TABLE players (id INTEGER);\n-- +goose Down\nDROP TABLE players;\n")},
+		"2_scores.sql":  {Data: []byte("-- +goose Up\nCREATE TABLE scores (id INTEGER);\n-- +goose Down\nDROP TABLE scores;\n")},
+	}
+
+	locker := new(recordingLocker)
+
+	migrator, err := newMigrator(sqlDB, goose.DialectSQLite3, fsys, locker)
+	require.NoError(t, err)
+
+	return migrator, sqlDB, locker
+}
+
+func TestMigratorRedoHoldsOneLock(t *testing.T) {
+	t.Parallel()
+
+	migrator, sqlDB, locker := newTestMigrator(t)
+
+	require.NoError(t, migrator.Up(t.Context()))
+
+	_, err := sqlDB.ExecContext(t.Context(), "INSERT INTO scores (id) VALUES (1)")
+	require.NoError(t, err)
+
+	locker.calls = nil
+	require.NoError(t, migrator.Redo(t.Context()))
+	assert.Equal(t, []string{"lock", "unlock"}, locker.calls)
+
+	// The latest migration was rolled back and applied again
+	var count int
+	require.NoError(t, sqlDB.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM scores").Scan(&count))
+	assert.Zero(t, count)
+
+	version, err := migrator.Version(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, migrator.LatestVersion(), version)
+}
+
+func TestMigratorRedoFailureReleasesLock(t *testing.T) {
+	t.Parallel()
+
+	migrator, _, locker := newTestMigrator(t)
+
+	// Nothing is applied, so there is nothing to roll back
+	require.Error(t, migrator.Redo(t.Context()))
+	assert.Equal(t, []string{"lock", "unlock"}, locker.calls)
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type sessionKey struct{}
+
+// Session remembers the last write of a client so that its reads can be pinned to the primary.
+type Session struct {
+	mu        sync.Mutex
+	lastWrite time.Time
+}
+
+// WithSession returns a context carrying a new session whose last write was at lastWrite, zero if unknown.
+func WithSession(ctx context.Context, lastWrite time.Time) (context.Context, *Session) {
+	session := &Session{lastWrite: lastWrite}
+
+	return context.WithValue(ctx, sessionKey{}, session), session
+}
+
+func sessionFrom(ctx context.Context) (*Session, bool) {
+	session, ok := ctx.Value(sessionKey{}).(*Session)
+
+	return session, ok
+}
+
+// LastWrite returns the time of the last write in the session.
+func (s *Session) LastWrite() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lastWrite
+}
+
+func (s *Session) markWrite(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastWrite = at
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// Transaction runs fn in a transaction on db. Repositories that get their connection from [Conn]
+// with the context passed to fn join the transaction, so changes made by several repositories
+// commit or roll back together. Inside another transaction, fn runs in a nested one.
+func Transaction(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	return Conn(ctx, db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Conn returns the transaction started by [Transaction] that ctx carries, or db otherwise.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := txFrom(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
+
+func txFrom(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+
+	return tx, ok
+}
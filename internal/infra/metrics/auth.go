@@ -0,0 +1,39 @@
+// Package metrics provides Prometheus implementations of the observers that services accept.
+package metrics
+
+import (
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// AuthMetrics counts logins and issued tokens.
+type AuthMetrics struct {
+	logins *prometheus.CounterVec
+	tokens *prometheus.CounterVec
+}
+
+// NewAuthMetrics creates the metrics and registers them with registerer, unless it is nil.
+func NewAuthMetrics(registerer prometheus.Registerer) *AuthMetrics {
+	factory := promauto.With(registerer)
+
+	return &AuthMetrics{
+		logins: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_logins_total",
+			Help: "Login attempts by method and outcome.",
+		}, []string{"method", "outcome"}),
+		tokens: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_tokens_issued_total",
+			Help: "Issued tokens by kind.",
+		}, []string{"kind"}),
+	}
+}
+
+func (m *AuthMetrics) ObserveLogin(method models.LoginMethod, outcome models.LoginOutcome) {
+	m.logins.WithLabelValues(string(method), string(outcome)).Inc()
+}
+
+func (m *AuthMetrics) ObserveTokenIssued(kind string) {
+	m.tokens.WithLabelValues(kind).Inc()
+}
@@ -0,0 +1,16 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RegisterLogDrops exposes the number of log records dropped by saturated log outputs.
+func RegisterLogDrops(registerer prometheus.Registerer, dropped func() uint64) {
+	promauto.With(registerer).NewCounterFunc(prometheus.CounterOpts{
+		Name: "log_records_dropped_total",
+		Help: "Log records dropped because an output could not keep up.",
+	}, func() float64 {
+		return float64(dropped())
+	})
+}
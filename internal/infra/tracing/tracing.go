@@ -0,0 +1,136 @@
+// Package tracing sets up OpenTelemetry tracing.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/google/uuid"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Init installs the global tracer provider and W3C trace context propagation. Spans get trace IDs
+// even when no exporter is configured, so that logs can always be joined with traces. The returned
+// function flushes pending spans and must be called on shutdown.
+func Init(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithIDGenerator(NewIDGenerator(uuid.NewV7)),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+	}
+
+	closeOutput := func() error { return nil }
+
+	var exporter sdktrace.SpanExporter
+
+	switch cfg.Exporter {
+	case "":
+	case "otlp":
+		otlpOptions := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint)}
+
+		var err error
+		if exporter, err = otlptracehttp.New(ctx, otlpOptions...); err != nil {
+			return nil, fmt.Errorf("new otlp exporter: %w", err)
+		}
+	case "stdout":
+		var err error
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout)); err != nil {
+			return nil, fmt.Errorf("new stdout exporter: %w", err)
+		}
+	case "file":
+		const permission = 0o644
+
+		file, err := os.OpenFile(cfg.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, permission)
+		if err != nil {
+			return nil, fmt.Errorf("open file %s: %w", cfg.File, err)
+		}
+
+		closeOutput = file.Close
+
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(file)); err != nil {
+			return nil, fmt.Errorf("new file exporter: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+
+	if exporter != nil {
+		options = append(options,
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		)
+	} else {
+		options = append(options, sdktrace.WithSampler(sdktrace.NeverSample()))
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return func(ctx context.Context) error {
+		if err := provider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("shutdown tracer provider: %w", err)
+		}
+
+		if err := closeOutput(); err != nil {
+			return fmt.Errorf("close trace file: %w", err)
+		}
+
+		return nil
+	}, nil
+}
+
+var _ sdktrace.IDGenerator = (*IDGenerator)(nil)
+
+// IDGenerator makes trace IDs from UUIDs, so that they stay time-ordered with UUIDv7 as before
+// tracing existed. A root span takes the trace ID from the request ID in the context when it has
+// the form of one, so that a caller without traceparent can still find its trace.
+type IDGenerator struct {
+	newUUID func() (uuid.UUID, error)
+}
+
+func NewIDGenerator(newUUID func() (uuid.UUID, error)) *IDGenerator {
+	return &IDGenerator{newUUID: newUUID}
+}
+
+func (g *IDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	requestID, _ := slogx.RequestID(ctx)
+
+	traceID, ok := slogx.TraceIDFromRequestID(requestID)
+	if ok {
+		return traceID, g.newSpanID()
+	}
+
+	if id, err := g.newUUID(); err == nil {
+		traceID = trace.TraceID(id)
+	} else {
+		_, _ = rand.Read(traceID[:])
+	}
+
+	return traceID, g.newSpanID()
+}
+
+func (g *IDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	return g.newSpanID()
+}
+
+func (g *IDGenerator) newSpanID() trace.SpanID {
+	var id trace.SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+
+	return id
+}
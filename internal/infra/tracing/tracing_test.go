@@ -0,0 +1,39 @@
+package tracing_test
+
+import (
+	"testing"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/tracing"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDGenerator(t *testing.T) {
+	t.Parallel()
+
+	generator := tracing.NewIDGenerator(func() (uuid.UUID, error) {
+		return uuid.MustParse("0192a6f0-7a1b-7c3d-8e4f-5a6b7c8d9e0f"), nil
+	})
+
+	traceID, spanID := generator.NewIDs(t.Context())
+	assert.Equal(t, "0192a6f07a1b7c3d8e4f5a6b7c8d9e0f", traceID.String())
+	assert.True(t, spanID.IsValid())
+	assert.NotEqual(t, spanID, generator.NewSpanID(t.Context(), traceID))
+}
+
+func TestIDGeneratorRequestID(t *testing.T) {
+	t.Parallel()
+
+	generator := tracing.NewIDGenerator(func() (uuid.UUID, error) {
+		return uuid.MustParse("0192a6f0-7a1b-7c3d-8e4f-5a6b7c8d9e0f"), nil
+	})
+
+	ctx := slogx.WithRequestID(t.Context(), "11111111111111111111111111111111")
+	traceID, _ := generator.NewIDs(ctx)
+	assert.Equal(t, "11111111111111111111111111111111", traceID.String())
+
+	ctx = slogx.WithRequestID(t.Context(), "order-42")
+	traceID, _ = generator.NewIDs(ctx)
+	assert.Equal(t, "0192a6f07a1b7c3d8e4f5a6b7c8d9e0f", traceID.String())
+}
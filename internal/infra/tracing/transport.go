@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/tracing"
+
+// Transport starts a client span per outgoing request and propagates the trace context and the
+// request ID of the incoming request, so that downstream services join the same trace.
+type Transport struct {
+	base http.RoundTripper
+}
+
+// NewTransport wraps base, or [http.DefaultTransport] when base is nil.
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{base: base}
+}
+
+// NewClient returns an HTTP client with a [Transport] and the given timeout.
+func NewClient(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: NewTransport(nil), Timeout: timeout}
+}
+
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(tracerName).Start(request.Context(), "HTTP "+request.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(request.Method),
+			semconv.ServerAddress(request.URL.Hostname()),
+			semconv.URLFull(request.URL.Redacted()),
+		),
+	)
+	defer span.End()
+
+	// RoundTrip must not modify the caller's request.
+	request = request.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+	if requestID, ok := slogx.RequestID(ctx); ok && request.Header.Get(slogx.RequestIDHeader) == "" {
+		request.Header.Set(slogx.RequestIDHeader, requestID)
+	}
+
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, fmt.Errorf("round trip: %w", err)
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(response.StatusCode))
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(response.StatusCode))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,39 @@
+// Package models contains the persistent types of the audit log.
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entry is a single immutable audit log record. Entries form a hash chain:
+// Hash covers the entry's own fields and PrevHash, the hash of the preceding entry.
+type Entry struct {
+	Seq        int64 `gorm:"primaryKey;autoIncrement"`
+	CreatedAt  time.Time
+	ActorType  string     `gorm:"type:varchar(20)"`
+	ActorID    *uuid.UUID `gorm:"type:uuid"`
+	Action     string     `gorm:"type:varchar(100)"`
+	TargetType string     `gorm:"type:varchar(50)"`
+	TargetID   string     `gorm:"type:varchar(255)"`
+	Diff       string     `gorm:"type:json"`
+	TraceID    string     `gorm:"type:varchar(64)"`
+	IP         string     `gorm:"type:varchar(45)"`
+	PrevHash   string     `gorm:"type:char(64)"`
+	Hash       string     `gorm:"type:char(64)"`
+}
+
+func (Entry) TableName() string {
+	return "audit_log"
+}
+
+// EntryFilter narrows down audit log queries. Zero fields are ignored.
+type EntryFilter struct {
+	ActorID    *uuid.UUID
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+}
@@ -0,0 +1,5 @@
+package models
+
+import "errors"
+
+var ErrEntryNotFound = errors.New("audit entry not found")
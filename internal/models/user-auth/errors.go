@@ -6,6 +6,22 @@ var (
 	ErrUserNotFound     = errors.New("user not found")
 	ErrInvalidPassword  = errors.New("invalid password")
 	ErrInvalidAuthToken = errors.New("invalid authorization jwt token")
+	ErrUserBanned       = errors.New("user is banned")
+	ErrInvalidRole      = errors.New("invalid role")
+	ErrPhoneTaken       = errors.New("phone number is verified by another user")
+	ErrUserExists       = errors.New("user already exists")
+
+	// ErrInvalidCredentials is answered to failed logins whether the user or the password is wrong.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	ErrImpersonationForbidden = errors.New("user cannot be impersonated")
+
+	ErrOneTimeCodeNotFound = errors.New("one-time code not found")
+	ErrInvalidOneTimeCode  = errors.New("invalid or expired one-time code")
+	ErrOneTimeCodeUsed     = errors.New("one-time code already used")
+	ErrTooManyRequests     = errors.New("too many requests")
+
+	ErrOneTimeCodeAttemptsExhausted = errors.New("one-time code has no attempts left")
 
 	ErrPostNotFound = errors.New("post not found")
 )
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LoginMethod string
+
+const (
+	LoginMethodPassword LoginMethod = "password"
+	LoginMethodGoogle   LoginMethod = "google"
+	LoginMethodRefresh  LoginMethod = "refresh"
+	LoginMethodEmailOTP LoginMethod = "email_otp"
+	LoginMethodPhoneOTP LoginMethod = "phone_otp"
+)
+
+type LoginOutcome string
+
+const (
+	LoginOutcomeSuccess LoginOutcome = "success"
+	LoginOutcomeFailure LoginOutcome = "failure"
+)
+
+const (
+	LoginFailureUserNotFound    = "user_not_found"
+	LoginFailureInvalidPassword = "invalid_password"
+	LoginFailureInvalidToken    = "invalid_token"
+	LoginFailureUserBanned      = "user_banned"
+	LoginFailureInvalidCode     = "invalid_code"
+	LoginFailureInvalidClaims   = "invalid_claims"
+	LoginFailureMissingEmail    = "missing_email"
+)
+
+// LoginEvent is an append-only record of a single authentication attempt.
+type LoginEvent struct {
+	ID            uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        *uuid.UUID `gorm:"type:uuid"` // Empty when the attempt could not be linked to a user
+	Email         string     `gorm:"type:varchar(255)"`
+	Method        LoginMethod
+	Outcome       LoginOutcome
+	FailureReason string `gorm:"type:varchar(50)"`
+	IP            string `gorm:"type:varchar(45)"`
+	UserAgent     string `gorm:"type:text"`
+	CreatedAt     time.Time
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OneTimeCodePurpose string
+
+const (
+	OneTimeCodePurposeEmailLogin  OneTimeCodePurpose = "email_login"
+	OneTimeCodePurposePhoneVerify OneTimeCodePurpose = "phone_verify"
+	OneTimeCodePurposePhoneLogin  OneTimeCodePurpose = "phone_login"
+)
+
+// OneTimeCode is a short-lived, single-use secret sent to an email address or phone number.
+// Only keyed hashes of the code, link token and requesting device are stored.
+type OneTimeCode struct {
+	ID            uuid.UUID          `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Purpose       OneTimeCodePurpose `gorm:"type:varchar(30)"`
+	Destination   string             `gorm:"type:varchar(255)"`
+	CodeHash      string             `gorm:"type:char(64)"`
+	LinkTokenHash string             `gorm:"type:char(64)"`
+	DeviceHash    string             `gorm:"type:char(64)"`
+	IP            string             `gorm:"type:varchar(45)"`
+	Attempts      int
+	ExpiresAt     time.Time
+	ConsumedAt    *time.Time
+	CreatedAt     time.Time
+}
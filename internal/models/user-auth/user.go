@@ -7,17 +7,38 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	RoleAdmin     = "ADMIN"
+	RoleModerator = "MODERATOR"
+	RoleUser      = "USER"
+)
+
+var Roles = []string{RoleAdmin, RoleModerator, RoleUser}
+
+const (
+	StatusActive  = "ACTIVE"
+	StatusBanned  = "BANNED"
+	StatusPending = "PENDING"
+	StatusDeleted = "DELETED"
+)
+
+const (
+	LoginProviderLocal = "local"
+	LoginProviderEmail = "email"
+)
+
 type User struct {
 	gorm.Model
 	ID uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 
 	// Identity
-	Email        string `gorm:"uniqueIndex;not null"`
-	Username     string `gorm:"uniqueIndex;not null"`
-	PasswordHash string `gorm:"not null"`
-	Phone        string `gorm:"type:varchar(20)"`
-	IsVerified   bool   `gorm:"default:false"`
-	Status       string `gorm:"type:varchar(20);default:'ACTIVE'"` // ACTIVE, BANNED, PENDING, DELETED
+	Email           string `gorm:"uniqueIndex;not null"`
+	Username        string `gorm:"uniqueIndex;default:null"` // optional, stored as NULL when empty
+	PasswordHash    string `gorm:"not null"`
+	Phone           string `gorm:"type:varchar(20)"` // E.164
+	PhoneVerifiedAt *time.Time
+	IsVerified      bool   `gorm:"default:false"`
+	Status          string `gorm:"type:varchar(20);default:'ACTIVE'"` // ACTIVE, BANNED, PENDING, DELETED
 
 	// Profile
 	FullName    string `gorm:"type:varchar(255)"`
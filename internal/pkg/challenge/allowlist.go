@@ -0,0 +1,57 @@
+package challenge
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/netip"
+)
+
+// Allowlist exempts trusted clients, such as internal services or load tests, from challenges.
+type Allowlist struct {
+	prefixes   []netip.Prefix
+	clientKeys [][]byte
+}
+
+// NewAllowlist creates an allowlist of IP ranges in CIDR notation and client keys.
+func NewAllowlist(cidrs, clientKeys []string) (*Allowlist, error) {
+	allowlist := &Allowlist{}
+
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("parse cidr %q: %w", cidr, err)
+		}
+
+		allowlist.prefixes = append(allowlist.prefixes, prefix.Masked())
+	}
+
+	for _, clientKey := range clientKeys {
+		if clientKey != "" {
+			allowlist.clientKeys = append(allowlist.clientKeys, []byte(clientKey))
+		}
+	}
+
+	return allowlist, nil
+}
+
+// Allows reports whether the client IP address is in an allowed range or the client key is allowed.
+func (a *Allowlist) Allows(ip, clientKey string) bool {
+	if addr, err := netip.ParseAddr(ip); err == nil {
+		addr = addr.Unmap()
+		for _, prefix := range a.prefixes {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+	}
+
+	if clientKey != "" {
+		for _, allowed := range a.clientKeys {
+			if subtle.ConstantTimeCompare([]byte(clientKey), allowed) == 1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
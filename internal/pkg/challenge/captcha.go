@@ -0,0 +1,89 @@
+package challenge
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CaptchaVerifier checks a response token produced by a captcha widget.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, response, remoteIP string) error
+}
+
+var (
+	_ CaptchaVerifier = (*SiteVerify)(nil)
+	_ CaptchaVerifier = (*StaticCaptcha)(nil)
+)
+
+// SiteVerify checks tokens with a "siteverify" endpoint as implemented by reCAPTCHA, hCaptcha and
+// Cloudflare Turnstile.
+type SiteVerify struct {
+	client *http.Client
+	url    string
+	secret string
+}
+
+func NewSiteVerify(client *http.Client, url, secret string) *SiteVerify {
+	return &SiteVerify{client: client, url: url, secret: secret}
+}
+
+func (v *SiteVerify) Verify(ctx context.Context, response, remoteIP string) error {
+	form := url.Values{"secret": {v.secret}, "response": {response}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpResponse, err := v.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", httpResponse.StatusCode)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+
+	if err := json.NewDecoder(httpResponse.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	if !result.Success {
+		return fmt.Errorf("rejected with %v: %w", result.ErrorCodes, ErrChallengeFailed)
+	}
+
+	return nil
+}
+
+// StaticCaptcha accepts a single fixed token. It stands in for a real provider in tests and local development.
+type StaticCaptcha struct {
+	token string
+}
+
+func NewStaticCaptcha(token string) *StaticCaptcha {
+	return &StaticCaptcha{token: token}
+}
+
+func (v *StaticCaptcha) Verify(_ context.Context, response, _ string) error {
+	if v.token == "" || subtle.ConstantTimeCompare([]byte(response), []byte(v.token)) != 1 {
+		return ErrChallengeFailed
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package challenge_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/challenge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solve(t *testing.T, c challenge.Challenge) string {
+	t.Helper()
+
+	for counter := 0; ; counter++ {
+		solution := strconv.Itoa(counter)
+		hash := sha256.Sum256([]byte(c.Token + ":" + solution))
+
+		zeros := 0
+		for _, b := range hash {
+			if b != 0 {
+				for b&0x80 == 0 {
+					zeros++
+					b <<= 1
+				}
+
+				break
+			}
+
+			zeros += 8
+		}
+
+		if zeros >= c.Difficulty {
+			return solution
+		}
+	}
+}
+
+func TestProofOfWork(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	pow, err := challenge.NewProofOfWork(func() time.Time { return now }, []byte("secret"), 8, time.Minute)
+	require.NoError(t, err)
+
+	c, err := pow.Issue()
+	require.NoError(t, err)
+	assert.Equal(t, 8, c.Difficulty)
+
+	solution := solve(t, c)
+
+	require.NoError(t, pow.Verify(c.Token, solution))
+	require.ErrorIs(t, pow.Verify(c.Token, solution), challenge.ErrChallengeFailed, "solution must not be reusable")
+}
+
+func TestProofOfWorkRejects(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	pow, err := challenge.NewProofOfWork(clock, []byte("secret"), 12, time.Minute)
+	require.NoError(t, err)
+
+	other, err := challenge.NewProofOfWork(clock, []byte("other secret"), 0, time.Minute)
+	require.NoError(t, err)
+
+	c, err := pow.Issue()
+	require.NoError(t, err)
+
+	foreign, err := other.Issue()
+	require.NoError(t, err)
+
+	t.Run("wrong solution", func(t *testing.T) {
+		t.Parallel()
+
+		// One in 4096 solutions is accidentally valid; find one that is not.
+		solution := "x"
+		for pow.Verify(c.Token, solution) == nil {
+			solution += "x"
+		}
+
+		assert.ErrorIs(t, pow.Verify(c.Token, solution), challenge.ErrChallengeFailed)
+	})
+
+	t.Run("foreign signature", func(t *testing.T) {
+		t.Parallel()
+
+		assert.ErrorIs(t, pow.Verify(foreign.Token, solve(t, foreign)), challenge.ErrChallengeFailed)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+
+		assert.ErrorIs(t, pow.Verify("not a token", "0"), challenge.ErrChallengeFailed)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		later, err := challenge.NewProofOfWork(func() time.Time { return now.Add(2 * time.Minute) }, []byte("secret"), 12, time.Minute)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, later.Verify(c.Token, solve(t, c)), challenge.ErrChallengeFailed)
+	})
+}
+
+func TestNewProofOfWorkValidates(t *testing.T) {
+	t.Parallel()
+
+	_, err := challenge.NewProofOfWork(time.Now, nil, 8, time.Minute)
+	require.Error(t, err)
+
+	_, err = challenge.NewProofOfWork(time.Now, []byte("secret"), challenge.MaxDifficulty+1, time.Minute)
+	require.Error(t, err)
+}
+
+func TestAllowlist(t *testing.T) {
+	t.Parallel()
+
+	allowlist, err := challenge.NewAllowlist([]string{"10.0.0.0/8", "2001:db8::/32"}, []string{"load-test"})
+	require.NoError(t, err)
+
+	assert.True(t, allowlist.Allows("10.1.2.3", ""))
+	assert.True(t, allowlist.Allows("::ffff:10.1.2.3", ""))
+	assert.True(t, allowlist.Allows("2001:db8::1", ""))
+	assert.True(t, allowlist.Allows("203.0.113.1", "load-test"))
+	assert.False(t, allowlist.Allows("203.0.113.1", "other"))
+	assert.False(t, allowlist.Allows("", ""))
+
+	_, err = challenge.NewAllowlist([]string{"10.0.0.0"}, nil)
+	require.Error(t, err)
+}
+
+func TestStaticCaptcha(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, challenge.NewStaticCaptcha("pass").Verify(context.Background(), "pass", ""))
+	require.ErrorIs(t, challenge.NewStaticCaptcha("pass").Verify(context.Background(), "fail", ""), challenge.ErrChallengeFailed)
+	require.ErrorIs(t, challenge.NewStaticCaptcha("").Verify(context.Background(), "", ""), challenge.ErrChallengeFailed)
+}
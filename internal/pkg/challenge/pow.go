@@ -0,0 +1,151 @@
+// Package challenge verifies that a request comes from a client willing to spend effort, to slow down
+// automated sign-ups. It provides a built-in proof-of-work challenge and an interface for external
+// captcha providers.
+package challenge
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrChallengeRequired = errors.New("challenge required")
+	ErrChallengeFailed   = errors.New("challenge failed")
+)
+
+// MaxDifficulty bounds the difficulty so that a misconfiguration cannot lock out every client.
+const MaxDifficulty = 32
+
+const nonceLength = 16
+
+// Challenge is a signed proof-of-work puzzle. The client must find a solution such that
+// SHA-256(Token + ":" + solution) starts with Difficulty zero bits.
+type Challenge struct {
+	Token      string
+	Difficulty int
+	ExpiresAt  time.Time
+}
+
+// ProofOfWork issues stateless, signed challenges and verifies their solutions.
+//
+// Solved challenges are remembered until they expire so that a solution cannot be reused. The memory
+// is per process: with several instances a solution can be replayed once per instance.
+type ProofOfWork struct {
+	now        func() time.Time
+	secret     []byte
+	difficulty int
+	ttl        time.Duration
+
+	mu     sync.Mutex
+	solved map[string]time.Time
+}
+
+func NewProofOfWork(now func() time.Time, secret []byte, difficulty int, ttl time.Duration) (*ProofOfWork, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("secret is empty")
+	}
+
+	if difficulty < 0 || difficulty > MaxDifficulty {
+		return nil, fmt.Errorf("difficulty %d is out of range [0, %d]", difficulty, MaxDifficulty)
+	}
+
+	return &ProofOfWork{
+		now:        now,
+		secret:     secret,
+		difficulty: difficulty,
+		ttl:        ttl,
+		solved:     make(map[string]time.Time),
+	}, nil
+}
+
+func (p *ProofOfWork) Issue() (Challenge, error) {
+	payload := make([]byte, nonceLength+8+1)
+	if _, err := rand.Read(payload[:nonceLength]); err != nil {
+		return Challenge{}, fmt.Errorf("read random nonce: %w", err)
+	}
+
+	expiresAt := p.now().Add(p.ttl).Truncate(time.Second)
+	binary.BigEndian.PutUint64(payload[nonceLength:], uint64(expiresAt.Unix()))
+	payload[nonceLength+8] = byte(p.difficulty)
+
+	token := base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(p.sign(payload))
+
+	return Challenge{Token: token, Difficulty: p.difficulty, ExpiresAt: expiresAt}, nil
+}
+
+// Verify checks that the challenge was issued by p, has not expired or been solved before, and that
+// solution solves it.
+func (p *ProofOfWork) Verify(token, solution string) error {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return fmt.Errorf("malformed token: %w", ErrChallengeFailed)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != nonceLength+8+1 {
+		return fmt.Errorf("malformed payload: %w", ErrChallengeFailed)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, p.sign(payload)) {
+		return fmt.Errorf("invalid signature: %w", ErrChallengeFailed)
+	}
+
+	now := p.now()
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[nonceLength:])), 0)
+	if !now.Before(expiresAt) {
+		return fmt.Errorf("expired: %w", ErrChallengeFailed)
+	}
+
+	// The difficulty is signed into the token, so lowering it later does not weaken issued challenges.
+	difficulty := int(payload[nonceLength+8])
+	if leadingZeroBits(sha256.Sum256([]byte(token+":"+solution))) < difficulty {
+		return fmt.Errorf("wrong solution: %w", ErrChallengeFailed)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for solvedToken, solvedExpiresAt := range p.solved {
+		if !now.Before(solvedExpiresAt) {
+			delete(p.solved, solvedToken)
+		}
+	}
+
+	if _, ok := p.solved[token]; ok {
+		return fmt.Errorf("already solved: %w", ErrChallengeFailed)
+	}
+
+	p.solved[token] = expiresAt
+
+	return nil
+}
+
+func (p *ProofOfWork) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+func leadingZeroBits(hash [sha256.Size]byte) int {
+	zeros := 0
+	for _, b := range hash {
+		if b != 0 {
+			return zeros + bits.LeadingZeros8(b)
+		}
+
+		zeros += 8
+	}
+
+	return zeros
+}
@@ -0,0 +1,25 @@
+// Package clientinfo carries information about the calling client through a request context.
+package clientinfo
+
+import "context"
+
+type Info struct {
+	IP        string
+	UserAgent string
+	// ClientID names the app that sent the request, if it said so. It is not authenticated.
+	ClientID string
+}
+
+type infoKeyType int8
+
+var infoKey infoKeyType = 1
+
+func WithInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, infoKey, info)
+}
+
+// FromContext returns client information stored in the context or an empty Info.
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(infoKey).(Info)
+	return info
+}
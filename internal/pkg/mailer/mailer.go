@@ -0,0 +1,82 @@
+// Package mailer sends transactional emails.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+var (
+	_ Mailer = (*LogMailer)(nil)
+	_ Mailer = (*SMTPMailer)(nil)
+)
+
+// LogMailer writes messages to the log instead of sending them. Use it for local development only:
+// messages may contain one-time codes.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, message Message) error {
+	slog.InfoContext(ctx, "Email not sent, logged instead",
+		"to", message.To,
+		"subject", message.Subject,
+		"text", message.Text,
+	)
+
+	return nil
+}
+
+// SMTPMailer sends plain text messages through an SMTP server.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer creates a mailer for the given server. Authentication is skipped if username is empty.
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, port),
+		from: from,
+		auth: auth,
+	}
+}
+
+func (m *SMTPMailer) Send(_ context.Context, message Message) error {
+	var body strings.Builder
+	body.WriteString("From: " + m.from + "\r\n")
+	body.WriteString("To: " + message.To + "\r\n")
+	body.WriteString("Subject: " + message.Subject + "\r\n")
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	body.WriteString("\r\n")
+	body.WriteString(message.Text)
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{message.To}, []byte(body.String())); err != nil {
+		return fmt.Errorf("send mail via smtp: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+// Package password hashes and verifies user passwords.
+//
+// New hashes are produced with argon2id in PHC string format. Legacy bcrypt hashes are still
+// accepted by [Service.Verify], which reports them as needing a rehash so callers can upgrade
+// them transparently after a successful login.
+package password
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var tracer = otel.Tracer("github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/password")
+
+// algorithmKey records the hash algorithm on spans.
+const algorithmKey = attribute.Key("password.algorithm")
+
+var (
+	ErrMismatchedPassword = errors.New("password does not match hash")
+	ErrUnsupportedHash    = errors.New("unsupported password hash format")
+)
+
+// PasswordHasher hashes new passwords and verifies passwords against stored hashes.
+type PasswordHasher interface {
+	Hash(ctx context.Context, password string) (string, error)
+	// Verify returns [ErrMismatchedPassword] if the password does not match. needsRehash
+	// reports whether the stored hash should be replaced with a fresh [PasswordHasher.Hash].
+	Verify(ctx context.Context, encodedHash, password string) (needsRehash bool, err error)
+}
+
+var _ PasswordHasher = (*Service)(nil)
+
+// Service is the default [PasswordHasher]. Hashing is memory and CPU heavy, so the number of
+// concurrent hash computations is bounded; callers over the limit wait for a free slot.
+type Service struct {
+	argon2id  *Argon2idHasher
+	semaphore chan struct{}
+}
+
+// NewService creates a hasher that allows at most maxConcurrent simultaneous hash computations.
+// A non-positive maxConcurrent defaults to the number of CPUs.
+func NewService(params Argon2idParams, maxConcurrent int) *Service {
+	if maxConcurrent <= 0 {
+		maxConcurrent = runtime.NumCPU()
+	}
+
+	return &Service{
+		argon2id:  NewArgon2idHasher(params),
+		semaphore: make(chan struct{}, maxConcurrent),
+	}
+}
+
+func (s *Service) Hash(ctx context.Context, password string) (_ string, err error) {
+	ctx, span := tracer.Start(ctx, "password.Hash", trace.WithAttributes(algorithmKey.String("argon2id")))
+	defer func() { endSpan(span, err) }()
+
+	if err := s.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer s.release()
+
+	hash, err := s.argon2id.Hash(password)
+	if err != nil {
+		return "", fmt.Errorf("hash password with argon2id: %w", err)
+	}
+
+	return hash, nil
+}
+
+func (s *Service) Verify(ctx context.Context, encodedHash, password string) (_ bool, err error) {
+	ctx, span := tracer.Start(ctx, "password.Verify")
+	defer func() { endSpan(span, err) }()
+
+	if err := s.acquire(ctx); err != nil {
+		return false, err
+	}
+	defer s.release()
+
+	switch {
+	case strings.HasPrefix(encodedHash, argon2idPrefix):
+		span.SetAttributes(algorithmKey.String("argon2id"))
+
+		return s.argon2id.Verify(encodedHash, password)
+	case isBcrypt(encodedHash):
+		span.SetAttributes(algorithmKey.String("bcrypt"))
+
+		err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, ErrMismatchedPassword
+		} else if err != nil {
+			return false, fmt.Errorf("compare bcrypt hash: %w", errors.Join(err, ErrUnsupportedHash))
+		}
+
+		return true, nil
+	default:
+		return false, ErrUnsupportedHash
+	}
+}
+
+// endSpan ends a span, marking it failed unless the password merely did not match.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, ErrMismatchedPassword) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "password hashing failed")
+	}
+
+	span.End()
+}
+
+func (s *Service) acquire(ctx context.Context) error {
+	select {
+	case s.semaphore <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for password hashing slot: %w", ctx.Err())
+	}
+}
+
+func (s *Service) release() {
+	<-s.semaphore
+}
+
+func isBcrypt(encodedHash string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(encodedHash, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testParams = Argon2idParams{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestServiceArgon2id(t *testing.T) {
+	service := NewService(testParams, 1)
+
+	hash, err := service.Hash(t.Context(), "correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), hash)
+
+	needsRehash, err := service.Verify(t.Context(), hash, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.False(t, needsRehash)
+
+	_, err = service.Verify(t.Context(), hash, "wrong password")
+	require.ErrorIs(t, err, ErrMismatchedPassword)
+
+	stronger := testParams
+	stronger.Iterations = 2
+
+	needsRehash, err = NewService(stronger, 1).Verify(t.Context(), hash, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, needsRehash)
+}
+
+func TestServiceBcrypt(t *testing.T) {
+	service := NewService(testParams, 1)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("11111111"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	needsRehash, err := service.Verify(t.Context(), string(hash), "11111111")
+	require.NoError(t, err)
+	assert.True(t, needsRehash)
+
+	_, err = service.Verify(t.Context(), string(hash), "22222222")
+	require.ErrorIs(t, err, ErrMismatchedPassword)
+}
+
+func TestServiceUnsupportedHash(t *testing.T) {
+	service := NewService(testParams, 1)
+
+	for _, hash := range []string{"", "plain", "$argon2id$v=19$broken", "$argon2i$v=19$m=64,t=1,p=1$c2FsdA$aGFzaA"} {
+		_, err := service.Verify(t.Context(), hash, "password")
+		require.ErrorIs(t, err, ErrUnsupportedHash, hash)
+	}
+}
@@ -0,0 +1,273 @@
+package policy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1" //nolint:gosec // SHA-1 is the format breach corpora are distributed in, not a security boundary here.
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const digestSize = sha1.Size
+
+// BreachedCorpus is an offline set of breached passwords stored as a sorted file of raw
+// SHA-1 digests, 20 bytes each. Lookups binary-search the file on disk, so memory usage
+// stays constant regardless of the corpus size and no network calls are made.
+type BreachedCorpus struct {
+	file  *os.File
+	count int64
+}
+
+func OpenBreachedCorpus(path string) (*BreachedCorpus, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached corpus %s: %w", path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("stat breached corpus %s: %w", path, err)
+	}
+
+	if info.Size()%digestSize != 0 {
+		file.Close()
+		return nil, fmt.Errorf("breached corpus %s: size %d is not a multiple of %d", path, info.Size(), digestSize)
+	}
+
+	return &BreachedCorpus{file: file, count: info.Size() / digestSize}, nil
+}
+
+func (c *BreachedCorpus) Contains(password string) (bool, error) {
+	target := sha1.Sum([]byte(password)) //nolint:gosec // See import comment.
+	buffer := make([]byte, digestSize)
+
+	var readErr error
+
+	index := sort.Search(int(c.count), func(i int) bool {
+		if readErr != nil {
+			return true
+		}
+
+		if _, err := c.file.ReadAt(buffer, int64(i)*digestSize); err != nil {
+			readErr = err
+			return true
+		}
+
+		return bytes.Compare(buffer, target[:]) >= 0
+	})
+	if readErr != nil {
+		return false, fmt.Errorf("read breached corpus: %w", readErr)
+	}
+
+	if int64(index) >= c.count {
+		return false, nil
+	}
+
+	if _, err := c.file.ReadAt(buffer, int64(index)*digestSize); err != nil {
+		return false, fmt.Errorf("read breached corpus: %w", err)
+	}
+
+	return bytes.Equal(buffer, target[:]), nil
+}
+
+func (c *BreachedCorpus) Close() error {
+	if err := c.file.Close(); err != nil {
+		return fmt.Errorf("close breached corpus: %w", err)
+	}
+
+	return nil
+}
+
+// corpusChunkDigests is how many digests BuildBreachedCorpus sorts in memory at a time, 20 MiB.
+const corpusChunkDigests = 1 << 20
+
+// BuildBreachedCorpus reads one entry per line and writes a sorted, deduplicated corpus.
+// A line is either a plain password or a hex SHA-1 digest optionally followed by ":count",
+// the format of the Have I Been Pwned downloads. The source is sorted in chunks spilled to
+// temporary files, which are then merged, so memory usage does not grow with the source.
+func BuildBreachedCorpus(r io.Reader, w io.Writer) (int, error) {
+	return buildBreachedCorpus(r, w, corpusChunkDigests)
+}
+
+func buildBreachedCorpus(r io.Reader, w io.Writer, chunkDigests int) (int, error) {
+	var runs []*os.File
+
+	defer func() {
+		for _, run := range runs {
+			run.Close()
+			os.Remove(run.Name())
+		}
+	}()
+
+	chunk := make([][digestSize]byte, 0, chunkDigests)
+
+	spill := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+
+		run, err := os.CreateTemp("", "breached-corpus-*")
+		if err != nil {
+			return fmt.Errorf("create corpus run: %w", err)
+		}
+
+		runs = append(runs, run)
+
+		if _, err := writeSortedDigests(run, chunk); err != nil {
+			return err
+		}
+
+		if _, err := run.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("rewind corpus run: %w", err)
+		}
+
+		chunk = chunk[:0]
+
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		chunk = append(chunk, lineDigest(line))
+
+		if len(chunk) == chunkDigests {
+			if err := spill(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("read corpus source: %w", err)
+	}
+
+	// A source that fits in one chunk needs no temporary file.
+	if len(runs) == 0 {
+		return writeSortedDigests(w, chunk)
+	}
+
+	if err := spill(); err != nil {
+		return 0, err
+	}
+
+	return mergeRuns(runs, w)
+}
+
+// writeSortedDigests sorts and deduplicates digests and writes them to w.
+func writeSortedDigests(w io.Writer, digests [][digestSize]byte) (int, error) {
+	slices.SortFunc(digests, func(a, b [digestSize]byte) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	digests = slices.Compact(digests)
+
+	writer := bufio.NewWriter(w)
+	for _, digest := range digests {
+		if _, err := writer.Write(digest[:]); err != nil {
+			return 0, fmt.Errorf("write corpus: %w", err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return 0, fmt.Errorf("flush corpus: %w", err)
+	}
+
+	return len(digests), nil
+}
+
+// mergeRuns merges sorted runs of digests into w, dropping duplicates across runs.
+func mergeRuns(runs []*os.File, w io.Writer) (int, error) {
+	readers := make([]*bufio.Reader, len(runs))
+	heads := make([][digestSize]byte, len(runs))
+	live := make([]int, 0, len(runs))
+
+	next := func(i int) (bool, error) {
+		if _, err := io.ReadFull(readers[i], heads[i][:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return false, nil
+			}
+
+			return false, fmt.Errorf("read corpus run: %w", err)
+		}
+
+		return true, nil
+	}
+
+	for i, run := range runs {
+		readers[i] = bufio.NewReader(run)
+
+		ok, err := next(i)
+		if err != nil {
+			return 0, err
+		}
+
+		if ok {
+			live = append(live, i)
+		}
+	}
+
+	writer := bufio.NewWriter(w)
+
+	var (
+		count int
+		last  [digestSize]byte
+	)
+
+	for len(live) > 0 {
+		// Runs are few, so a linear scan for the smallest head is enough.
+		smallest := 0
+		for j := 1; j < len(live); j++ {
+			if bytes.Compare(heads[live[j]][:], heads[live[smallest]][:]) < 0 {
+				smallest = j
+			}
+		}
+
+		i := live[smallest]
+		if count == 0 || heads[i] != last {
+			if _, err := writer.Write(heads[i][:]); err != nil {
+				return 0, fmt.Errorf("write corpus: %w", err)
+			}
+
+			last = heads[i]
+			count++
+		}
+
+		ok, err := next(i)
+		if err != nil {
+			return 0, err
+		}
+
+		if !ok {
+			live = slices.Delete(live, smallest, smallest+1)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return 0, fmt.Errorf("flush corpus: %w", err)
+	}
+
+	return count, nil
+}
+
+func lineDigest(line string) [digestSize]byte {
+	hexDigest, _, _ := strings.Cut(line, ":")
+
+	var digest [digestSize]byte
+	if len(hexDigest) == hex.EncodedLen(digestSize) {
+		if _, err := hex.Decode(digest[:], []byte(hexDigest)); err == nil {
+			return digest
+		}
+	}
+
+	return sha1.Sum([]byte(line)) //nolint:gosec // See import comment.
+}
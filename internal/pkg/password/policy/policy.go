@@ -0,0 +1,240 @@
+// Package policy decides whether a password is acceptable for an account.
+//
+// A [Policy] checks length limits, banned patterns, similarity to the account's identity
+// (email, username, name), an estimated strength score and, optionally, membership in an
+// offline corpus of breached passwords. All violations are collected and returned together
+// so clients can show every problem at once.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	CodeTooShort        = "too_short"
+	CodeTooLong         = "too_long"
+	CodeBannedPattern   = "banned_pattern"
+	CodeSimilarIdentity = "similar_to_identity"
+	CodeTooWeak         = "too_weak"
+	CodeBreached        = "breached"
+)
+
+var ErrPolicyViolation = errors.New("password violates policy")
+
+// Violation is a single machine-readable reason for rejecting a password.
+type Violation struct {
+	Code    string `json:"code" example:"too_short"`
+	Message string `json:"message" example:"Password must be at least 8 characters long"`
+}
+
+// ViolationsError lists every policy rule a password broke. It matches [ErrPolicyViolation] with errors.Is.
+type ViolationsError struct {
+	Violations []Violation
+}
+
+func (e *ViolationsError) Error() string {
+	codes := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		codes = append(codes, violation.Code)
+	}
+
+	return fmt.Sprintf("%s: %s", ErrPolicyViolation, strings.Join(codes, ", "))
+}
+
+func (e *ViolationsError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+type Config struct {
+	MinLength int
+	MaxLength int
+	// BannedPatterns are case-insensitive regular expressions the password must not match.
+	BannedPatterns []string
+	// MaxSimilarity in [0, 1] is the highest allowed similarity to the account's identity. Zero disables the check.
+	MaxSimilarity float64
+	// MinScore in [0, 4] is the lowest allowed strength score, see [Score].
+	MinScore int
+}
+
+type breachedCorpus interface {
+	Contains(password string) (bool, error)
+}
+
+type Policy struct {
+	config         Config
+	bannedPatterns []*regexp.Regexp
+	breached       breachedCorpus
+}
+
+// New compiles the policy. breached may be nil to skip the breached-password check.
+func New(config Config, breached breachedCorpus) (*Policy, error) {
+	patterns := make([]*regexp.Regexp, 0, len(config.BannedPatterns))
+	for _, pattern := range config.BannedPatterns {
+		compiled, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("compile banned pattern %q: %w", pattern, err)
+		}
+
+		patterns = append(patterns, compiled)
+	}
+
+	return &Policy{config: config, bannedPatterns: patterns, breached: breached}, nil
+}
+
+// Check validates the password. identity holds the account's email, username, name and similar
+// values the password should not resemble. It returns a *[ViolationsError] if any rule is broken.
+func (p *Policy) Check(password string, identity ...string) error {
+	var violations []Violation
+
+	length := utf8.RuneCountInString(password)
+	if length < p.config.MinLength {
+		violations = append(violations, Violation{
+			Code:    CodeTooShort,
+			Message: fmt.Sprintf("Password must be at least %d characters long", p.config.MinLength),
+		})
+	}
+	if p.config.MaxLength > 0 && length > p.config.MaxLength {
+		violations = append(violations, Violation{
+			Code:    CodeTooLong,
+			Message: fmt.Sprintf("Password must be at most %d characters long", p.config.MaxLength),
+		})
+	}
+
+	for _, pattern := range p.bannedPatterns {
+		if pattern.MatchString(password) {
+			violations = append(violations, Violation{
+				Code:    CodeBannedPattern,
+				Message: "Password contains a banned word or pattern",
+			})
+
+			break
+		}
+	}
+
+	identityTokens := identityTokens(identity)
+	if p.config.MaxSimilarity > 0 && tooSimilar(password, identityTokens, p.config.MaxSimilarity) {
+		violations = append(violations, Violation{
+			Code:    CodeSimilarIdentity,
+			Message: "Password is too similar to your email, username or name",
+		})
+	}
+
+	if Score(password, identityTokens...) < p.config.MinScore {
+		violations = append(violations, Violation{
+			Code:    CodeTooWeak,
+			Message: "Password is too easy to guess",
+		})
+	}
+
+	if p.breached != nil {
+		breached, err := p.breached.Contains(password)
+		if err != nil {
+			return fmt.Errorf("check breached passwords: %w", err)
+		}
+
+		if breached {
+			violations = append(violations, Violation{
+				Code:    CodeBreached,
+				Message: "Password has appeared in a data breach",
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ViolationsError{Violations: violations}
+	}
+
+	return nil
+}
+
+// identityTokens splits identity values into lowercase tokens worth comparing against,
+// e.g. "john.doe@example.com" yields "john.doe@example.com", "john.doe", "john" and "doe".
+func identityTokens(identity []string) []string {
+	const minTokenLength = 3
+
+	var tokens []string
+
+	add := func(token string) {
+		if utf8.RuneCountInString(token) >= minTokenLength {
+			tokens = append(tokens, token)
+		}
+	}
+
+	for _, value := range identity {
+		value = strings.ToLower(strings.TrimSpace(value))
+		add(value)
+
+		local, _, isEmail := strings.Cut(value, "@")
+		if isEmail {
+			add(local)
+		}
+
+		for _, part := range strings.FieldsFunc(local, func(r rune) bool {
+			return strings.ContainsRune(" ._-+", r)
+		}) {
+			add(part)
+		}
+	}
+
+	return tokens
+}
+
+func tooSimilar(password string, tokens []string, maxSimilarity float64) bool {
+	const minContainedLength = 4
+
+	lower := strings.ToLower(password)
+
+	for _, token := range tokens {
+		if utf8.RuneCountInString(token) >= minContainedLength && strings.Contains(lower, token) {
+			return true
+		}
+
+		if similarity(lower, token) > maxSimilarity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// similarity is 1 minus the Levenshtein distance normalized by the longer string's length.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
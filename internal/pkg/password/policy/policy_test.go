@@ -0,0 +1,132 @@
+package policy
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		password string
+		identity []string
+		maxScore int
+		minScore int
+	}{
+		{password: "password", maxScore: 0},
+		{password: "P@ssw0rd", maxScore: 1},
+		{password: "aaaaaaaaaaaa", maxScore: 1},
+		{password: "abcdefgh", maxScore: 1},
+		{password: "qwertyuiop", maxScore: 1},
+		{password: "johndoe1990", identity: []string{"johndoe"}, maxScore: 1},
+		{password: "minecraft2024", maxScore: 2},
+		{password: "correct horse battery staple", minScore: 4, maxScore: 4},
+		{password: "x7$Kq!p2Vz#m", minScore: 4, maxScore: 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.password, func(t *testing.T) {
+			score := Score(test.password, test.identity...)
+			assert.GreaterOrEqual(t, score, test.minScore)
+			assert.LessOrEqual(t, score, test.maxScore)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	policy, err := New(Config{
+		MinLength:      8,
+		MaxLength:      64,
+		BannedPatterns: []string{"gameplatform"},
+		MaxSimilarity:  0.7,
+		MinScore:       2,
+	}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, policy.Check("x7$Kq!p2Vz#m", "john.doe@example.com", "John Doe"))
+
+	err = policy.Check("GamePlatform", "john.doe@example.com")
+	require.ErrorIs(t, err, ErrPolicyViolation)
+	assert.Equal(t, []string{CodeBannedPattern}, violationCodes(t, err))
+
+	err = policy.Check("john.doe", "john.doe@example.com")
+	assert.Equal(t, []string{CodeSimilarIdentity, CodeTooWeak}, violationCodes(t, err))
+
+	err = policy.Check("abc", "john.doe@example.com")
+	assert.Equal(t, []string{CodeTooShort, CodeTooWeak}, violationCodes(t, err))
+
+	err = policy.Check(strings.Repeat("x7$Kq!p2", 10))
+	assert.Equal(t, []string{CodeTooLong}, violationCodes(t, err))
+}
+
+func TestBreachedCorpus(t *testing.T) {
+	source := strings.Join([]string{
+		"hunter2",
+		"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493", // SHA-1 of "password"
+		"hunter2",
+		"trustno1",
+	}, "\n")
+
+	var corpus bytes.Buffer
+	count, err := BuildBreachedCorpus(strings.NewReader(source), &corpus)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	path := filepath.Join(t.TempDir(), "breached.bin")
+	require.NoError(t, os.WriteFile(path, corpus.Bytes(), 0o600))
+
+	breached, err := OpenBreachedCorpus(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { breached.Close() })
+
+	for password, want := range map[string]bool{
+		"hunter2":  true,
+		"password": true,
+		"trustno1": true,
+		"hunter3":  false,
+		"":         false,
+	} {
+		got, err := breached.Contains(password)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, password)
+	}
+
+	policy, err := New(Config{}, breached)
+	require.NoError(t, err)
+	assert.Equal(t, []string{CodeBreached}, violationCodes(t, policy.Check("hunter2")))
+}
+
+func TestBuildBreachedCorpusInChunks(t *testing.T) {
+	source := strings.Join([]string{"d", "b", "a", "c", "b", "e", "a", "f", "c"}, "\n")
+
+	var whole bytes.Buffer
+	count, err := BuildBreachedCorpus(strings.NewReader(source), &whole)
+	require.NoError(t, err)
+	assert.Equal(t, 6, count)
+
+	// Two digests per chunk spills five sorted runs that are merged with duplicates dropped
+	var chunked bytes.Buffer
+	count, err = buildBreachedCorpus(strings.NewReader(source), &chunked, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 6, count)
+	assert.Equal(t, whole.Bytes(), chunked.Bytes())
+}
+
+func violationCodes(t *testing.T, err error) []string {
+	t.Helper()
+
+	var violationsErr *ViolationsError
+	require.ErrorAs(t, err, &violationsErr)
+
+	codes := make([]string, 0, len(violationsErr.Violations))
+	for _, violation := range violationsErr.Violations {
+		codes = append(codes, violation.Code)
+	}
+
+	return codes
+}
@@ -0,0 +1,274 @@
+package policy
+
+import (
+	"bufio"
+	_ "embed"
+	"math"
+	"strings"
+	"unicode"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+// commonPasswords maps a common password or word to its popularity rank, starting at 1.
+var commonPasswords = loadRanked(commonPasswordsFile)
+
+var keyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+var leetSubstitutions = strings.NewReplacer(
+	"4", "a", "@", "a", "3", "e", "1", "i", "!", "i", "0", "o", "5", "s", "$", "s", "7", "t",
+)
+
+const (
+	minMatchLength      = 3
+	maxDictionaryLength = 32
+	identityRank        = 1
+	yearGuesses         = 120
+)
+
+// Score estimates how hard a password is to guess on a 0-4 scale, in the spirit of zxcvbn:
+//
+//	0: too guessable (< 10^3 guesses)
+//	1: very guessable (< 10^6 guesses)
+//	2: somewhat guessable (< 10^8 guesses)
+//	3: safely unguessable (< 10^10 guesses)
+//	4: very unguessable
+//
+// The password is split into the cheapest sequence of known patterns (common passwords and
+// words, identity values, l33t variants, repeats, sequences, keyboard runs and years), with
+// everything else brute-forced over the password's character set.
+func Score(password string, identity ...string) int {
+	log10Guesses := estimateLog10Guesses(password, identity)
+
+	switch {
+	case log10Guesses < 3:
+		return 0
+	case log10Guesses < 6:
+		return 1
+	case log10Guesses < 8:
+		return 2
+	case log10Guesses < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func estimateLog10Guesses(password string, identity []string) float64 {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0
+	}
+
+	lower := []rune(strings.ToLower(password))
+	bruteForce := math.Log10(float64(cardinality(runes)))
+
+	dictionary := make(map[string]int, len(identity))
+	for _, value := range identity {
+		dictionary[strings.ToLower(value)] = identityRank
+	}
+
+	// best[i] is the cheapest log10 guesses for the first i characters.
+	best := make([]float64, len(runes)+1)
+	for end := 1; end <= len(runes); end++ {
+		best[end] = best[end-1] + bruteForce
+
+		for start := max(0, end-maxDictionaryLength); start <= end-minMatchLength; start++ {
+			if guesses := matchGuesses(runes[start:end], lower[start:end], dictionary); guesses > 0 {
+				// Each extra pattern costs a little, so one long match beats many short ones.
+				best[end] = min(best[end], best[start]+math.Log10(guesses)+segmentPenalty(start))
+			}
+		}
+	}
+
+	return best[len(runes)]
+}
+
+func segmentPenalty(start int) float64 {
+	if start == 0 {
+		return 0
+	}
+
+	return 1
+}
+
+// matchGuesses returns the number of guesses needed for the substring if it matches a known pattern, or 0.
+func matchGuesses(original, lower []rune, dictionary map[string]int) float64 {
+	word := string(lower)
+
+	var guesses float64
+
+	consider := func(candidate float64) {
+		if candidate > 0 && (guesses == 0 || candidate < guesses) {
+			guesses = candidate
+		}
+	}
+
+	uppercaseFactor := 1.0
+	if hasUpper(original) {
+		uppercaseFactor = 2
+	}
+
+	if rank, ok := lookup(word, dictionary); ok {
+		consider(float64(rank) * uppercaseFactor)
+	}
+
+	if unleet := leetSubstitutions.Replace(word); unleet != word {
+		if rank, ok := lookup(unleet, dictionary); ok {
+			const leetFactor = 2
+			consider(float64(rank) * uppercaseFactor * leetFactor)
+		}
+	}
+
+	if isRepeat(lower) {
+		consider(float64(cardinality(original[:1])) * float64(len(lower)))
+	}
+
+	if isSequence(lower) {
+		consider(float64(cardinality(original[:1])) * float64(len(lower)))
+	}
+
+	if isKeyboardRun(word) {
+		const keyboardStarts = 40
+		consider(keyboardStarts * float64(len(lower)))
+	}
+
+	if isYear(word) {
+		consider(yearGuesses)
+	}
+
+	return guesses
+}
+
+func lookup(word string, dictionary map[string]int) (int, bool) {
+	if rank, ok := dictionary[word]; ok {
+		return rank, true
+	}
+
+	rank, ok := commonPasswords[word]
+
+	return rank, ok
+}
+
+func cardinality(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+	if lower {
+		size += 26
+	}
+	if upper {
+		size += 26
+	}
+	if digit {
+		size += 10
+	}
+	if symbol {
+		size += 33
+	}
+	if other {
+		size += 100
+	}
+
+	return max(size, 10)
+}
+
+func hasUpper(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isRepeat(runes []rune) bool {
+	for _, r := range runes[1:] {
+		if r != runes[0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isSequence(runes []rune) bool {
+	delta := runes[1] - runes[0]
+	if delta != 1 && delta != -1 {
+		return false
+	}
+
+	for i := 2; i < len(runes); i++ {
+		if runes[i]-runes[i-1] != delta {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isKeyboardRun(word string) bool {
+	const minKeyboardRun = 4
+
+	if len(word) < minKeyboardRun {
+		return false
+	}
+
+	reversed := []rune(word)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+
+	for _, row := range keyboardRows {
+		if strings.Contains(row, word) || strings.Contains(row, string(reversed)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isYear(word string) bool {
+	if len(word) != 4 || (!strings.HasPrefix(word, "19") && !strings.HasPrefix(word, "20")) {
+		return false
+	}
+
+	for _, r := range word {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func loadRanked(list string) map[string]int {
+	ranked := make(map[string]int)
+
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if _, seen := ranked[word]; word != "" && !seen {
+			ranked[word] = len(ranked) + 1
+		}
+	}
+
+	return ranked
+}
@@ -0,0 +1,103 @@
+// Package phone normalizes phone numbers to E.164.
+//
+// Only the country calling codes of the regions we operate in are known, so numbers written in
+// national format can be expanded using a default region. Numbers already in international format
+// are accepted for any country.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidNumber = errors.New("invalid phone number")
+
+// callingCodes maps ISO 3166-1 alpha-2 region codes to country calling codes.
+var callingCodes = map[string]string{
+	"BN": "673",
+	"ID": "62",
+	"KH": "855",
+	"LA": "856",
+	"MM": "95",
+	"MY": "60",
+	"PH": "63",
+	"SG": "65",
+	"TH": "66",
+	"TL": "670",
+	"VN": "84",
+}
+
+const (
+	// E.164 numbers have at most 15 digits. Shorter than 8 digits is never a mobile number.
+	minDigits = 8
+	maxDigits = 15
+
+	// National numbers without the trunk prefix that start with the calling code and are at least
+	// this long are assumed to include the calling code already.
+	minDigitsWithCallingCode = 10
+)
+
+// KnownRegion reports whether national numbers of the region can be normalized.
+func KnownRegion(region string) bool {
+	_, ok := callingCodes[strings.ToUpper(region)]
+
+	return ok
+}
+
+// Normalize converts the number to E.164, for example "+84912345678". Spaces, dots, dashes and
+// parentheses are ignored. Numbers starting with "+" or "00" are international; other numbers are
+// national numbers of defaultRegion, with the leading trunk prefix "0" removed.
+func Normalize(number, defaultRegion string) (string, error) {
+	digits, international, err := clean(number)
+	if err != nil {
+		return "", err
+	}
+
+	if !international {
+		code, ok := callingCodes[strings.ToUpper(defaultRegion)]
+		if !ok {
+			return "", fmt.Errorf("unknown region %q: %w", defaultRegion, ErrInvalidNumber)
+		}
+
+		// Some users type the calling code without the "+". A trunk prefix means they did not.
+		national, hasTrunkPrefix := strings.CutPrefix(digits, "0")
+		if hasTrunkPrefix || !strings.HasPrefix(digits, code) || len(digits) < minDigitsWithCallingCode {
+			digits = code + national
+		}
+	}
+
+	if len(digits) < minDigits || len(digits) > maxDigits || digits[0] == '0' {
+		return "", fmt.Errorf("%d digits: %w", len(digits), ErrInvalidNumber)
+	}
+
+	return "+" + digits, nil
+}
+
+func clean(number string) (digits string, international bool, err error) {
+	number = strings.TrimSpace(number)
+
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number, international = number[1:], true
+	case strings.HasPrefix(number, "00"):
+		number, international = number[2:], true
+	}
+
+	var b strings.Builder
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ', r == '.', r == '-', r == '(', r == ')':
+		default:
+			return "", false, fmt.Errorf("unexpected character %q: %w", r, ErrInvalidNumber)
+		}
+	}
+
+	if b.Len() == 0 {
+		return "", false, ErrInvalidNumber
+	}
+
+	return b.String(), international, nil
+}
@@ -0,0 +1,66 @@
+package phone_test
+
+import (
+	"testing"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/phone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		number        string
+		defaultRegion string
+		want          string
+	}{
+		{name: "international", number: "+84 912 345 678", defaultRegion: "VN", want: "+84912345678"},
+		{name: "international with 00", number: "0066 81-234-5678", defaultRegion: "VN", want: "+66812345678"},
+		{name: "international ignores default region", number: "+1 (415) 555-0100", defaultRegion: "VN", want: "+14155550100"},
+		{name: "national with trunk prefix", number: "0912.345.678", defaultRegion: "VN", want: "+84912345678"},
+		{name: "national without trunk prefix", number: "912345678", defaultRegion: "vn", want: "+84912345678"},
+		{name: "national starting with calling code", number: "0841234567", defaultRegion: "VN", want: "+84841234567"},
+		{name: "calling code without plus", number: "84912345678", defaultRegion: "VN", want: "+84912345678"},
+		{name: "short national number", number: "6512 3456", defaultRegion: "SG", want: "+6565123456"},
+		{name: "philippines", number: "0917 123 4567", defaultRegion: "PH", want: "+639171234567"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := phone.Normalize(test.number, test.defaultRegion)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestNormalizeInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		number        string
+		defaultRegion string
+	}{
+		{name: "empty", number: "", defaultRegion: "VN"},
+		{name: "letters", number: "+84 91234abcd", defaultRegion: "VN"},
+		{name: "too short", number: "+84 123", defaultRegion: "VN"},
+		{name: "too long", number: "+84 1234 5678 9012 34", defaultRegion: "VN"},
+		{name: "unknown region", number: "0912345678", defaultRegion: "US"},
+		{name: "leading zero after plus", number: "+0912345678", defaultRegion: "VN"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := phone.Normalize(test.number, test.defaultRegion)
+			assert.ErrorIs(t, err, phone.ErrInvalidNumber)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Package redact removes secrets from data before it is logged.
+//
+// Values are redacted when their key looks sensitive, matched case-insensitively as a substring
+// with "-" and "_" ignored, so that the pattern "token" covers "refresh_token" and "X-Auth-Token".
+// A pattern starting with "=" must match the whole key instead, for short names such as "otp".
+// JSON web tokens are redacted wherever they appear, whatever the key.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Placeholder replaces redacted values.
+const Placeholder = "[REDACTED]"
+
+// DefaultKeys are the key patterns redacted when none are configured.
+var DefaultKeys = []string{
+	"password", "token", "secret", "authorization", "cookie", "apikey", "privatekey", "credential", "=otp",
+}
+
+// OneTimeCodeKeys are the fields of request bodies that carry one-time codes. They are not among
+// [DefaultKeys] since responses use "code" for the code of a problem, which must stay readable.
+var OneTimeCodeKeys = []string{"=code"}
+
+// jwtPattern matches the header and payload of a JWS in compact form, which always start with "{".
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+type Redactor struct {
+	keys      []string
+	exactKeys []string
+}
+
+// New returns a redactor for the given key patterns, or for [DefaultKeys] when there are none.
+func New(keys []string) *Redactor {
+	if len(keys) == 0 {
+		keys = DefaultKeys
+	}
+
+	redactor := &Redactor{}
+	redactor.add(keys)
+
+	return redactor
+}
+
+// With returns a redactor that also redacts the given key patterns.
+func (r *Redactor) With(keys ...string) *Redactor {
+	redactor := &Redactor{keys: slices.Clone(r.keys), exactKeys: slices.Clone(r.exactKeys)}
+	redactor.add(keys)
+
+	return redactor
+}
+
+func (r *Redactor) add(keys []string) {
+	for _, key := range keys {
+		exact := strings.HasPrefix(key, "=")
+
+		key = normalizeKey(strings.TrimPrefix(key, "="))
+		switch {
+		case key == "":
+		case exact:
+			r.exactKeys = append(r.exactKeys, key)
+		default:
+			r.keys = append(r.keys, key)
+		}
+	}
+}
+
+// Key reports whether values stored under key must be redacted.
+func (r *Redactor) Key(key string) bool {
+	key = normalizeKey(key)
+
+	if slices.Contains(r.exactKeys, key) {
+		return true
+	}
+
+	for _, pattern := range r.keys {
+		if strings.Contains(key, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String redacts the JSON web tokens found in free text.
+func (r *Redactor) String(s string) string {
+	if !strings.Contains(s, "eyJ") {
+		return s
+	}
+
+	return jwtPattern.ReplaceAllString(s, Placeholder)
+}
+
+// JSON redacts a JSON document. Invalid JSON, such as a truncated document, is redacted as text
+// where the values of members with sensitive keys are found by scanning.
+func (r *Redactor) JSON(data []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return []byte(r.invalidJSON(string(data)))
+	}
+
+	redacted, err := json.Marshal(r.Value(value))
+	if err != nil {
+		return []byte(r.invalidJSON(string(data)))
+	}
+
+	return redacted
+}
+
+// jsonKeyPattern matches the name of a JSON member and its colon.
+var jsonKeyPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"\s*:\s*`)
+
+// invalidJSON replaces the value of each member with a sensitive key, whatever its type and
+// even if it is cut at the end, and copies other values as they are.
+func (r *Redactor) invalidJSON(data string) string {
+	var redacted strings.Builder
+
+	for {
+		match := jsonKeyPattern.FindStringSubmatchIndex(data)
+		if match == nil {
+			redacted.WriteString(data)
+
+			break
+		}
+
+		redacted.WriteString(data[:match[1]])
+		key := data[match[2]:match[3]]
+		data = data[match[1]:]
+
+		end := jsonValueEnd(data)
+		if r.Key(key) {
+			redacted.WriteString(`"` + Placeholder + `"`)
+		} else {
+			redacted.WriteString(data[:end])
+		}
+
+		data = data[end:]
+	}
+
+	return r.String(redacted.String())
+}
+
+// jsonValueEnd returns the length of the JSON value that data starts with, which is all of data if
+// the value is cut. Strings are skipped, so keys inside them are not mistaken for members.
+func jsonValueEnd(data string) int {
+	depth := 0
+	inString := false
+
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; {
+		case inString:
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			if depth == 0 {
+				return i
+			}
+
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case c == ',' && depth == 0:
+			return i
+		}
+	}
+
+	return len(data)
+}
+
+// Value redacts a value decoded from JSON, made of maps, slices, strings and scalars.
+func (r *Redactor) Value(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(value))
+		for key, item := range value {
+			if r.Key(key) {
+				redacted[key] = Placeholder
+			} else {
+				redacted[key] = r.Value(item)
+			}
+		}
+
+		return redacted
+	case []any:
+		redacted := make([]any, len(value))
+		for i, item := range value {
+			redacted[i] = r.Value(item)
+		}
+
+		return redacted
+	case string:
+		return r.String(value)
+	default:
+		return value
+	}
+}
+
+// Query returns a copy of values with sensitive values redacted.
+func (r *Redactor) Query(values url.Values) url.Values {
+	redacted := make(url.Values, len(values))
+	for key, items := range values {
+		redacted[key] = r.values(key, items)
+	}
+
+	return redacted
+}
+
+// RawQuery redacts a URL-encoded query string or form body. An invalid one is treated as free text.
+func (r *Redactor) RawQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return r.String(query)
+	}
+
+	return r.Query(values).Encode()
+}
+
+func (r *Redactor) values(key string, values []string) []string {
+	redacted := make([]string, len(values))
+	for i, value := range values {
+		if r.Key(key) {
+			redacted[i] = Placeholder
+		} else {
+			redacted[i] = r.String(value)
+		}
+	}
+
+	return redacted
+}
+
+func normalizeKey(key string) string {
+	key = strings.ToLower(key)
+	key = strings.ReplaceAll(key, "-", "")
+
+	return strings.ReplaceAll(key, "_", "")
+}
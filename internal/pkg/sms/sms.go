@@ -0,0 +1,80 @@
+// Package sms sends text messages to phone numbers.
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+type Message struct {
+	// To is a phone number in E.164 format.
+	To   string
+	Text string
+}
+
+// SMSSender delivers text messages. Implementations for a real gateway are expected to be added
+// next to the development stand-ins below.
+type SMSSender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+var (
+	_ SMSSender = (*LogSender)(nil)
+	_ SMSSender = (*FileSender)(nil)
+)
+
+// LogSender logs that a message would have been sent, without sending it. The text is left out, as
+// it holds one-time codes; use [FileSender] to read them.
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(ctx context.Context, message Message) error {
+	slog.InfoContext(ctx, "SMS not sent, logged instead", "to", message.To, "length", len(message.Text))
+
+	return nil
+}
+
+// FileSender appends messages to a file as JSON lines, so that tests and developers can read the codes.
+type FileSender struct {
+	now  func() time.Time
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSender(now func() time.Time, path string) *FileSender {
+	return &FileSender{now: now, path: path}
+}
+
+func (s *FileSender) Send(_ context.Context, message Message) error {
+	line, err := json.Marshal(struct {
+		SentAt time.Time `json:"sentAt"`
+		To     string    `json:"to"`
+		Text   string    `json:"text"`
+	}{s.now(), message.To, message.Text})
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package token
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -10,25 +12,59 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/golang-jwt/jwt/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token")
+
 type JwtCustomClaims struct {
 	FullName string    `json:"fullName"`
 	ID       uuid.UUID `json:"id"`
+	Role     string    `json:"role"`
+
+	// Act identifies the admin acting as the user in an impersonation token (RFC 8693, section 4.1).
+	Act *ActorClaim `json:"act,omitempty"`
+	// SessionID identifies the login the token descends from, see [WithSession].
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+type ActorClaim struct {
+	Subject uuid.UUID `json:"sub"`
+}
+
+// Impersonated reports whether the token was issued to an admin acting as the user.
+func (c *JwtCustomClaims) Impersonated() bool {
+	return c.Act != nil
+}
+
 type JwtCustomRefreshClaims struct {
-	ID uuid.UUID `json:"id"`
+	ID        uuid.UUID `json:"id"`
+	SessionID string    `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// Kinds of issued tokens, as reported to the issue observer.
+const (
+	KindAccess        = "access"
+	KindRefresh       = "refresh"
+	KindImpersonation = "impersonation"
+)
+
+type issueObserver interface {
+	ObserveTokenIssued(kind string)
+}
+
 type Service struct {
 	now                  func() time.Time
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
 	accessTokenSecret    []byte
 	refreshSecret        []byte
+	issueObserver        issueObserver
 }
 
 func NewService(
@@ -37,6 +73,7 @@ func NewService(
 	refreshTokenDuration time.Duration,
 	accessSecret []byte,
 	refreshSecret []byte,
+	issueObserver issueObserver,
 ) *Service {
 	return &Service{
 		now:                  now,
@@ -44,23 +81,24 @@ func NewService(
 		refreshTokenDuration: refreshTokenDuration,
 		accessTokenSecret:    accessSecret,
 		refreshSecret:        refreshSecret,
+		issueObserver:        issueObserver,
 	}
 }
 
-func (s *Service) CreateAccessToken(_ context.Context, user *models.User) (accessToken string, expires int64, err error) {
+func (s *Service) CreateAccessToken(ctx context.Context, user *models.User) (accessToken string, expires int64, err error) {
 	expiresAt := s.now().Add(s.accessTokenDuration)
 
 	claims := &JwtCustomClaims{
-		FullName: user.FullName,
-		ID:       user.ID,
+		FullName:  user.FullName,
+		ID:        user.ID,
+		Role:      user.Role,
+		SessionID: SessionFromContext(ctx),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	accessToken, err = token.SignedString(s.accessTokenSecret)
+	accessToken, err = s.sign(ctx, KindAccess, claims, s.accessTokenSecret)
 	if err != nil {
 		return "", 0, fmt.Errorf("sign access token: %w", err)
 	}
@@ -68,19 +106,54 @@ func (s *Service) CreateAccessToken(_ context.Context, user *models.User) (acces
 	return accessToken, expiresAt.Unix(), nil
 }
 
-func (s *Service) CreateRefreshToken(_ context.Context, user *models.User) (string, error) {
+// ImpersonationTokenExpiry returns when an impersonation token issued now for duration expires. The
+// lifetime is capped by the access token duration.
+func (s *Service) ImpersonationTokenExpiry(duration time.Duration) time.Time {
+	// JWT expiry has a precision of seconds
+	return s.now().Add(min(duration, s.accessTokenDuration)).Truncate(time.Second)
+}
+
+// CreateImpersonationToken creates an access token for user that carries actorID in the act claim and
+// expires at expiresAt, which comes from [Service.ImpersonationTokenExpiry]. No refresh token is issued
+// for impersonation.
+func (s *Service) CreateImpersonationToken(
+	ctx context.Context,
+	user *models.User,
+	actorID uuid.UUID,
+	expiresAt time.Time,
+) (string, error) {
+	claims := &JwtCustomClaims{
+		FullName: user.FullName,
+		ID:       user.ID,
+		Role:     user.Role,
+		Act:      &ActorClaim{Subject: actorID},
+		// Impersonation tokens are never refreshed, each is a session of its own.
+		SessionID: NewSessionID(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	accessToken, err := s.sign(ctx, KindImpersonation, claims, s.accessTokenSecret)
+	if err != nil {
+		return "", fmt.Errorf("sign impersonation token: %w", err)
+	}
+
+	return accessToken, nil
+}
+
+func (s *Service) CreateRefreshToken(ctx context.Context, user *models.User) (string, error) {
 	expiresAt := s.now().Add(s.refreshTokenDuration)
 
 	claims := &JwtCustomRefreshClaims{
-		ID: user.ID,
+		ID:        user.ID,
+		SessionID: SessionFromContext(ctx),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	signed, err := token.SignedString(s.refreshSecret)
+	signed, err := s.sign(ctx, KindRefresh, claims, s.refreshSecret)
 	if err != nil {
 		return "", fmt.Errorf("sign refresh token: %w", err)
 	}
@@ -106,6 +179,41 @@ func (s *Service) ParseRefreshToken(_ context.Context, token string) (*JwtCustom
 	return claims, nil
 }
 
+// KeyFingerprints returns short, non-reversible fingerprints of the signing keys by key name.
+// They allow detecting key rotations without ever exposing the keys.
+func (s *Service) KeyFingerprints() map[string]string {
+	return map[string]string{
+		"access":  fingerprint(s.accessTokenSecret),
+		"refresh": fingerprint(s.refreshSecret),
+	}
+}
+
+func fingerprint(key []byte) string {
+	const length = 8
+
+	sum := sha256.Sum256(key)
+
+	return hex.EncodeToString(sum[:length])
+}
+
+// sign signs claims in a span and counts the issued token.
+func (s *Service) sign(ctx context.Context, kind string, claims jwt.Claims, secret []byte) (string, error) {
+	_, span := tracer.Start(ctx, "token.Sign", trace.WithAttributes(attribute.String("token.kind", kind)))
+	defer span.End()
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "signing failed")
+
+		return "", err
+	}
+
+	s.issueObserver.ObserveTokenIssued(kind)
+
+	return signed, nil
+}
+
 func (s *Service) parseToken(token string, secret []byte, claims jwt.Claims) error {
 	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -0,0 +1,29 @@
+package token
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type sessionKeyType int8
+
+var sessionKey sessionKeyType = 1
+
+// WithSession stores the ID of the login session that the tokens created with the context belong
+// to. A login starts a session with [NewSessionID], and a refresh continues the session of the
+// refresh token.
+func WithSession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionKey, sessionID)
+}
+
+// SessionFromContext returns the session ID stored by [WithSession], or "".
+func SessionFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionKey).(string)
+
+	return sessionID
+}
+
+func NewSessionID() string {
+	return uuid.NewString()
+}
@@ -0,0 +1,149 @@
+// Package repositories persists audit log entries.
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/audit"
+
+	"gorm.io/gorm"
+)
+
+// appendLockKey serializes appends so that every entry is chained to its true predecessor.
+const appendLockKey = 7_274_861_530
+
+type EntryRepository struct {
+	db *gorm.DB
+}
+
+func NewEntryRepository(db *gorm.DB) *EntryRepository {
+	return &EntryRepository{db: db}
+}
+
+// Append inserts the entry after seal has filled in its hashes from the hash of the latest entry.
+// prevHash is empty for the very first entry. Inside a transaction started by [db.Transaction], the
+// entry commits together with it.
+func (r *EntryRepository) Append(ctx context.Context, entry *models.Entry, seal func(entry *models.Entry, prevHash string)) error {
+	err := db.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", appendLockKey).Error; err != nil {
+			return fmt.Errorf("acquire audit log lock: %w", err)
+		}
+
+		var last models.Entry
+		err := tx.Select("hash").Order("seq DESC").Take(&last).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("execute select last audit entry query: %w", err)
+		}
+
+		seal(entry, last.Hash)
+
+		if err := tx.Create(entry).Error; err != nil {
+			return fmt.Errorf("execute insert audit entry query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("append audit entry (tx): %w", err)
+	}
+
+	return nil
+}
+
+// List returns a page of entries matching the filter, newest first, together with the total count.
+func (r *EntryRepository) List(ctx context.Context, filter models.EntryFilter, limit, offset int) ([]models.Entry, int64, error) {
+	query := r.filtered(ctx, filter)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("execute count audit entries query: %w", err)
+	}
+
+	var entries []models.Entry
+	if err := query.Order("seq DESC").Limit(limit).Offset(offset).Find(&entries).Error; err != nil {
+		return nil, 0, fmt.Errorf("execute select audit entries query: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// Each calls fn for every entry matching the filter in chain order, loading batchSize entries at a time.
+func (r *EntryRepository) Each(ctx context.Context, filter models.EntryFilter, batchSize int, fn func(entry *models.Entry) error) error {
+	var afterSeq int64
+
+	for {
+		var batch []models.Entry
+		err := r.filtered(ctx, filter).Where("seq > ?", afterSeq).Order("seq ASC").Limit(batchSize).Find(&batch).Error
+		if err != nil {
+			return fmt.Errorf("execute select audit entries batch query: %w", err)
+		}
+
+		for i := range batch {
+			if err := fn(&batch[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(batch) < batchSize {
+			return nil
+		}
+
+		afterSeq = batch[len(batch)-1].Seq
+	}
+}
+
+// Locked runs fn in a transaction holding the append lock, so that no entry is appended by others
+// until fn returns. Reads and appends made with the context passed to fn join the transaction.
+func (r *EntryRepository) Locked(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.Transaction(ctx, r.db, func(ctx context.Context) error {
+		if err := db.Conn(ctx, r.db).Exec("SELECT pg_advisory_xact_lock(?)", appendLockKey).Error; err != nil {
+			return fmt.Errorf("acquire audit log lock: %w", err)
+		}
+
+		return fn(ctx)
+	})
+}
+
+// Latest returns the newest entry with the given action and target.
+func (r *EntryRepository) Latest(ctx context.Context, action, targetType, targetID string) (models.Entry, error) {
+	var entry models.Entry
+	err := db.Conn(ctx, r.db).
+		Where("action = ? AND target_type = ? AND target_id = ?", action, targetType, targetID).
+		Order("seq DESC").
+		Take(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Entry{}, errors.Join(models.ErrEntryNotFound, err)
+	} else if err != nil {
+		return models.Entry{}, fmt.Errorf("execute select latest audit entry query: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *EntryRepository) filtered(ctx context.Context, filter models.EntryFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.Entry{})
+
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	return query.Session(&gorm.Session{})
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+type LoginEventRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginEventRepository(db *gorm.DB) *LoginEventRepository {
+	return &LoginEventRepository{db: db}
+}
+
+func (r *LoginEventRepository) Create(ctx context.Context, event *models.LoginEvent) error {
+	if err := r.db.WithContext(ctx).Create(event).Error; err != nil {
+		return fmt.Errorf("execute insert login event query: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUserID returns a page of the user's login events, newest first, together with the total count.
+func (r *LoginEventRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.LoginEvent, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.LoginEvent{}).Where("user_id = ?", userID).Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("execute count login events query: %w", err)
+	}
+
+	var events []models.LoginEvent
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&events).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("execute select login events query: %w", err)
+	}
+
+	return events, total, nil
+}
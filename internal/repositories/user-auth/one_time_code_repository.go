@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+type OneTimeCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewOneTimeCodeRepository(db *gorm.DB) *OneTimeCodeRepository {
+	return &OneTimeCodeRepository{db: db}
+}
+
+func (r *OneTimeCodeRepository) Create(ctx context.Context, code *models.OneTimeCode) error {
+	if err := r.db.WithContext(ctx).Create(code).Error; err != nil {
+		return fmt.Errorf("execute insert one-time code query: %w", err)
+	}
+
+	return nil
+}
+
+// CountByDestinationSince counts codes issued for any of the purposes to the destination since the given time.
+func (r *OneTimeCodeRepository) CountByDestinationSince(
+	ctx context.Context,
+	purposes []models.OneTimeCodePurpose,
+	destination string,
+	since time.Time,
+) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.OneTimeCode{}).
+		Where("purpose IN ? AND destination = ? AND created_at >= ?", purposes, destination, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("execute count one-time codes by destination query: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountByIPSince counts codes for any of the purposes requested from the IP address since the given time.
+func (r *OneTimeCodeRepository) CountByIPSince(
+	ctx context.Context,
+	purposes []models.OneTimeCodePurpose,
+	ip string,
+	since time.Time,
+) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.OneTimeCode{}).
+		Where("purpose IN ? AND ip = ? AND created_at >= ?", purposes, ip, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("execute count one-time codes by ip query: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetLatestActive returns the newest unconsumed, unexpired code for the destination.
+func (r *OneTimeCodeRepository) GetLatestActive(
+	ctx context.Context,
+	purpose models.OneTimeCodePurpose,
+	destination string,
+	now time.Time,
+) (models.OneTimeCode, error) {
+	var code models.OneTimeCode
+	err := r.db.WithContext(ctx).
+		Where("purpose = ? AND destination = ? AND consumed_at IS NULL AND expires_at > ?", purpose, destination, now).
+		Order("created_at DESC").
+		Take(&code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.OneTimeCode{}, errors.Join(models.ErrOneTimeCodeNotFound, err)
+	} else if err != nil {
+		return models.OneTimeCode{}, fmt.Errorf("execute select active one-time code query: %w", err)
+	}
+
+	return code, nil
+}
+
+// GetActiveByLinkTokenHash returns the unconsumed, unexpired code with the given link token hash.
+func (r *OneTimeCodeRepository) GetActiveByLinkTokenHash(
+	ctx context.Context,
+	purpose models.OneTimeCodePurpose,
+	linkTokenHash string,
+	now time.Time,
+) (models.OneTimeCode, error) {
+	var code models.OneTimeCode
+	err := r.db.WithContext(ctx).
+		Where("purpose = ? AND link_token_hash = ? AND consumed_at IS NULL AND expires_at > ?", purpose, linkTokenHash, now).
+		Take(&code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.OneTimeCode{}, errors.Join(models.ErrOneTimeCodeNotFound, err)
+	} else if err != nil {
+		return models.OneTimeCode{}, fmt.Errorf("execute select one-time code by link token query: %w", err)
+	}
+
+	return code, nil
+}
+
+// UseAttempt counts a verification attempt against the code. The check and the increment are a single
+// statement, so concurrent guesses cannot exceed maxAttempts. It fails with
+// [models.ErrOneTimeCodeAttemptsExhausted] once the code has no attempts left.
+func (r *OneTimeCodeRepository) UseAttempt(ctx context.Context, id uuid.UUID, maxAttempts int) error {
+	result := r.db.WithContext(ctx).Model(&models.OneTimeCode{}).
+		Where("id = ? AND attempts < ?", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return fmt.Errorf("execute use one-time code attempt query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrOneTimeCodeAttemptsExhausted
+	}
+
+	return nil
+}
+
+// Consume marks the code as used. It fails with [models.ErrOneTimeCodeUsed] if another request consumed it first.
+func (r *OneTimeCodeRepository) Consume(ctx context.Context, id uuid.UUID, consumedAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.OneTimeCode{}).
+		Where("id = ? AND consumed_at IS NULL", id).
+		Update("consumed_at", consumedAt)
+	if result.Error != nil {
+		return fmt.Errorf("execute consume one-time code query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrOneTimeCodeUsed
+	}
+
+	return nil
+}
@@ -4,23 +4,43 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db"
 	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
 	"github.com/google/uuid"
 
 	"gorm.io/gorm"
 )
 
+const (
+	// verifiedPhoneIndex keeps a phone number verified by one user only.
+	verifiedPhoneIndex = "idx_users_verified_phone"
+	// emailIndex keeps an address, in any case, to one user only.
+	emailIndex = "idx_users_email_lower"
+)
+
+// UserRepository reads users from replicas when the cluster has them. A client that just changed a
+// user reads from the primary for a while, so it sees its own change.
 type UserRepository struct {
-	db *gorm.DB
+	cluster *db.Cluster
 }
 
-func NewUserRepository(db *gorm.DB) *UserRepository {
-	return &UserRepository{db: db}
+func NewUserRepository(cluster *db.Cluster) *UserRepository {
+	return &UserRepository{cluster: cluster}
 }
 
+// Transaction runs fn in a transaction. Repositories called with the context passed to fn join it.
+func (r *UserRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.cluster.Transaction(ctx, fn)
+}
+
+// Create fails with [models.ErrUserExists] if the email address, in any case, is taken.
 func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
-	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+	err := r.cluster.Writer(ctx).Create(user).Error
+	if db.IsUniqueViolation(err, emailIndex) {
+		return errors.Join(models.ErrUserExists, err)
+	} else if err != nil {
 		return fmt.Errorf("execute insert user query: %w", err)
 	}
 
@@ -29,7 +49,7 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (models.User, error) {
 	var user models.User
-	err := r.db.WithContext(ctx).Where("id = ?", id).Take(&user).Error
+	err := r.cluster.Reader(ctx).Where("id = ?", id).Take(&user).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return models.User{}, errors.Join(models.ErrUserNotFound, err)
 	} else if err != nil {
@@ -39,9 +59,11 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (models.User
 	return user, nil
 }
 
+// GetUserByEmail matches the address case-insensitively, since login codes are issued for the
+// lowercased address.
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
 	var user models.User
-	err := r.db.WithContext(ctx).Where("email = ?", email).Take(&user).Error
+	err := r.cluster.Reader(ctx).Where("LOWER(email) = LOWER(?)", email).Take(&user).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return models.User{}, errors.Join(models.ErrUserNotFound, err)
 	} else if err != nil {
@@ -51,32 +73,93 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (mode
 	return user, nil
 }
 
+// GetByVerifiedPhone returns the user who verified the phone number.
+func (r *UserRepository) GetByVerifiedPhone(ctx context.Context, phone string) (models.User, error) {
+	var user models.User
+	err := r.cluster.Reader(ctx).Where("phone = ? AND phone_verified_at IS NOT NULL", phone).Take(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.User{}, errors.Join(models.ErrUserNotFound, err)
+	} else if err != nil {
+		return models.User{}, fmt.Errorf("execute select user by verified phone query: %w", err)
+	}
+
+	return user, nil
+}
+
+// CreateUserAndOAuthProvider inserts the user and its provider link in one transaction, or in the
+// transaction carried by ctx. It fails like [UserRepository.Create] if the email address is taken.
 func (r *UserRepository) CreateUserAndOAuthProvider(ctx context.Context, user *models.User, oAuthProvider *models.OAuthProviders) error {
-	tx := r.db.Begin()
+	return r.cluster.Transaction(ctx, func(ctx context.Context) error {
+		if err := r.Create(ctx, user); err != nil {
+			return err
+		}
 
-	committed := false
+		oAuthProvider.UserID = user.ID
 
-	defer func() {
-		if !committed {
-			tx.Rollback()
+		if err := r.cluster.Writer(ctx).Create(oAuthProvider).Error; err != nil {
+			return fmt.Errorf("execute insert oauth provider query: %w", err)
 		}
-	}()
 
-	if err := tx.WithContext(ctx).Create(user).Error; err != nil {
-		return fmt.Errorf("insert user (tx): %w", err)
+		return nil
+	})
+}
+
+func (r *UserRepository) UpdateLastLoginAt(ctx context.Context, id uuid.UUID, lastLoginAt time.Time) error {
+	err := r.cluster.Writer(ctx).Model(&models.User{}).Where("id = ?", id).Update("last_login_at", lastLoginAt).Error
+	if err != nil {
+		return fmt.Errorf("execute update user last login query: %w", err)
 	}
 
-	oAuthProvider.UserID = user.ID
+	return nil
+}
 
-	if err := tx.WithContext(ctx).Create(oAuthProvider).Error; err != nil {
-		return fmt.Errorf("insert oauthprovider (tx): %w", err)
+func (r *UserRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	err := r.cluster.Writer(ctx).Model(&models.User{}).Where("id = ?", id).Update("status", status).Error
+	if err != nil {
+		return fmt.Errorf("execute update user status query: %w", err)
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("commit transaction: %w", err)
+	return nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role string) error {
+	err := r.cluster.Writer(ctx).Model(&models.User{}).Where("id = ?", id).Update("role", role).Error
+	if err != nil {
+		return fmt.Errorf("execute update user role query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	err := r.cluster.Writer(ctx).Model(&models.User{}).Where("id = ?", id).Update("password_hash", passwordHash).Error
+	if err != nil {
+		return fmt.Errorf("execute update user password hash query: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the email address verified and clears the password.
+func (r *UserRepository) VerifyEmail(ctx context.Context, id uuid.UUID) error {
+	err := r.cluster.Writer(ctx).Model(&models.User{}).Where("id = ?", id).
+		Updates(map[string]any{"is_verified": true, "password_hash": ""}).Error
+	if err != nil {
+		return fmt.Errorf("execute update user verified query: %w", err)
 	}
 
-	committed = true
+	return nil
+}
+
+// SetVerifiedPhone fails with [models.ErrPhoneTaken] if another user verified the number first.
+func (r *UserRepository) SetVerifiedPhone(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error {
+	err := r.cluster.Writer(ctx).Model(&models.User{}).Where("id = ?", id).
+		Updates(map[string]any{"phone": phone, "phone_verified_at": verifiedAt}).Error
+	if db.IsUniqueViolation(err, verifiedPhoneIndex) {
+		return errors.Join(models.ErrPhoneTaken, err)
+	} else if err != nil {
+		return fmt.Errorf("execute update user verified phone query: %w", err)
+	}
 
 	return nil
 }
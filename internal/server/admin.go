@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AdminServer serves operational endpoints, such as metrics, on a port that is not exposed publicly.
+type AdminServer struct {
+	mux    *http.ServeMux
+	server *http.Server
+}
+
+func NewAdminServer(port string) *AdminServer {
+	mux := http.NewServeMux()
+
+	return &AdminServer{
+		mux: mux,
+		server: &http.Server{
+			Addr:              ":" + port,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Handle registers handler for pattern, see [http.ServeMux].
+func (s *AdminServer) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *AdminServer) Start() error {
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("start admin server: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AdminServer) Shutdown(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("shutdown admin server: %w", err)
+	}
+
+	return nil
+}
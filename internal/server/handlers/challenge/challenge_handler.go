@@ -0,0 +1,44 @@
+// Package handlers provides HTTP handlers for bot protection challenges.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/challenge/responses"
+	commonResponses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/challenge"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=challenge_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type challengeIssuer interface {
+	Issue() (challenge.Challenge, error)
+}
+
+type ChallengeHandler struct {
+	challengeIssuer challengeIssuer
+}
+
+func NewChallengeHandler(challengeIssuer challengeIssuer) *ChallengeHandler {
+	return &ChallengeHandler{challengeIssuer: challengeIssuer}
+}
+
+// Issue godoc
+//
+//	@Summary		Get a proof-of-work challenge
+//	@Description	Issue a challenge required by sign-up and code requests. Find a solution such that SHA-256(challenge + ":" + solution) starts with the given number of zero bits, then send both in the X-Challenge and X-Challenge-Solution headers
+//	@ID				challenge-issue
+//	@Tags			Bot Protection
+//	@Produce		json
+//	@Success		200	{object}	responses.ChallengeResponse
+//	@Router			/challenge [get]
+func (h *ChallengeHandler) Issue(c echo.Context) error {
+	issued, err := h.challengeIssuer.Issue()
+	if err != nil {
+		return err
+	}
+
+	return commonResponses.Response(c, http.StatusOK, responses.NewChallengeResponse(issued))
+}
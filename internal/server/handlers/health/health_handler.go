@@ -0,0 +1,47 @@
+// Package handlers provides HTTP handlers for liveness and readiness probes.
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	commonResponses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/health/responses"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/health"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=health_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type readinessChecker interface {
+	Ready(ctx context.Context) health.Report
+}
+
+type HealthHandler struct {
+	readinessChecker readinessChecker
+}
+
+func NewHealthHandler(readinessChecker readinessChecker) *HealthHandler {
+	return &HealthHandler{readinessChecker: readinessChecker}
+}
+
+// Live reports that the process is running. Dependencies are not checked, so that a failing
+// database does not get every instance restarted. Served outside the API base path, so not in the
+// API documentation.
+func (h *HealthHandler) Live(c echo.Context) error {
+	return commonResponses.Response(c, http.StatusOK, &responses.HealthResponse{Status: responses.StatusOK})
+}
+
+// Ready reports whether the service can take traffic, with the result of each dependency check. It
+// fails with 503 as soon as the service starts shutting down.
+func (h *HealthHandler) Ready(c echo.Context) error {
+	report := h.readinessChecker.Ready(c.Request().Context())
+
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+
+	return commonResponses.Response(c, status, responses.NewHealthResponse(report))
+}
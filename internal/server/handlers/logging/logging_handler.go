@@ -0,0 +1,110 @@
+// Package handlers provides HTTP handlers for runtime log control.
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	commonResponses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/logging/requests"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/logging/responses"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=logging_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type logController interface {
+	Levels() logging.Levels
+	SetLevel(ctx context.Context, packagePath, levelName string) error
+	IssueDebugToken(ctx context.Context, ttl time.Duration) (string, time.Time, error)
+}
+
+type LoggingHandler struct {
+	logController logController
+}
+
+func NewLoggingHandler(logController logController) *LoggingHandler {
+	return &LoggingHandler{logController: logController}
+}
+
+// Levels godoc
+//
+//	@Summary		Get log levels
+//	@Description	Get the log level and its overrides by package. Admin only
+//	@ID				admin-log-levels
+//	@Tags			Admin Actions
+//	@Produce		json
+//	@Success		200	{object}	responses.LevelsResponse
+//	@Security		ApiKeyAuth
+//	@Router			/admin/log/levels [get]
+func (h *LoggingHandler) Levels(c echo.Context) error {
+	return commonResponses.Response(c, http.StatusOK, responses.NewLevelsResponse(h.logController.Levels()))
+}
+
+// SetLevel godoc
+//
+//	@Summary		Change a log level
+//	@Description	Change the log level, or override it for a package and its subpackages, until the next restart. Admin only
+//	@ID				admin-log-level-set
+//	@Tags			Admin Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.SetLevelRequest	true	"Package and level"
+//	@Success		200		{object}	responses.LevelsResponse
+//	@Failure		400		{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/admin/log/levels [put]
+func (h *LoggingHandler) SetLevel(c echo.Context) error {
+	var request requests.SetLevelRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	if err := h.logController.SetLevel(c.Request().Context(), request.Package, request.Level); err != nil {
+		return err
+	}
+
+	return commonResponses.Response(c, http.StatusOK, responses.NewLevelsResponse(h.logController.Levels()))
+}
+
+// IssueDebugToken godoc
+//
+//	@Summary		Issue a debug token
+//	@Description	Issue a token for the X-Debug-Token header, which logs the requests carrying it at DEBUG level until it expires. Admin only
+//	@ID				admin-log-debug-token
+//	@Tags			Admin Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.DebugTokenRequest	true	"Validity"
+//	@Success		201		{object}	responses.DebugTokenResponse
+//	@Failure		400		{object}	responses.Problem
+//	@Failure		501		{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/admin/log/debug-tokens [post]
+func (h *LoggingHandler) IssueDebugToken(c echo.Context) error {
+	var request requests.DebugTokenRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	// Validate checked the format.
+	ttl, _ := time.ParseDuration(request.TTL)
+
+	token, expiresAt, err := h.logController.IssueDebugToken(c.Request().Context(), ttl)
+	if err != nil {
+		return err
+	}
+
+	return commonResponses.Response(c, http.StatusCreated, &responses.DebugTokenResponse{Token: token, ExpiresAt: expiresAt})
+}
@@ -39,24 +39,26 @@ func NewAuthHandler(authService authService) *AuthHandler {
 //	@Produce		json
 //	@Param			params	body		requests.LoginRequest	true	"User's credentials"
 //	@Success		200		{object}	responses.LoginResponse
-//	@Failure		401		{object}	responses.Error
+//	@Failure		401		{object}	responses.Problem
+//	@Failure		403		{object}	responses.Problem
 //	@Router			/login [post]
 func (h *AuthHandler) Login(c echo.Context) error {
 	var request requests.LoginRequest
 	if err := c.Bind(&request); err != nil {
-		return commonResponses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request")
+		return err
 	}
 
 	if err := request.Validate(); err != nil {
-		return commonResponses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or not valid")
+		return err
 	}
 
 	response, err := h.authService.GenerateToken(c.Request().Context(), &request)
 	switch {
 	case errors.Is(err, models.ErrUserNotFound), errors.Is(err, models.ErrInvalidPassword):
-		return commonResponses.ErrorResponse(c, http.StatusUnauthorized, "Invalid credentials")
+		// Do not tell which one was wrong
+		return models.ErrInvalidCredentials
 	case err != nil:
-		return commonResponses.ErrorResponse(c, http.StatusInternalServerError, "Internal Server Error")
+		return err
 	}
 
 	return commonResponses.Response(c, http.StatusOK, response)
@@ -72,20 +74,22 @@ func (h *AuthHandler) Login(c echo.Context) error {
 //	@Produce		json
 //	@Param			params	body		requests.RefreshRequest	true	"Refresh token"
 //	@Success		200		{object}	responses.LoginResponse
-//	@Failure		401		{object}	responses.Error
+//	@Failure		401		{object}	responses.Problem
+//	@Failure		403		{object}	responses.Problem
 //	@Router			/refresh [post]
 func (h *AuthHandler) RefreshToken(c echo.Context) error {
 	var request requests.RefreshRequest
 	if err := c.Bind(&request); err != nil {
-		return commonResponses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request")
+		return err
 	}
 
 	response, err := h.authService.RefreshToken(c.Request().Context(), &request)
 	switch {
-	case errors.Is(err, models.ErrUserNotFound), errors.Is(err, models.ErrInvalidAuthToken):
-		return commonResponses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	case errors.Is(err, models.ErrUserNotFound):
+		// The user of the token was deleted
+		return models.ErrInvalidAuthToken
 	case err != nil:
-		return commonResponses.ErrorResponse(c, http.StatusInternalServerError, "Internal Server Error")
+		return err
 	}
 
 	return commonResponses.Response(c, http.StatusOK, response)
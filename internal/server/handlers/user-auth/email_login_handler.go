@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	commonResponses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=email_login_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type emailLoginService interface {
+	RequestLogin(ctx context.Context, request *requests.EmailLinkRequest) error
+	VerifyLogin(ctx context.Context, request *requests.EmailLinkVerifyRequest) (*responses.LoginResponse, error)
+}
+
+type EmailLoginHandler struct {
+	emailLoginService emailLoginService
+}
+
+func NewEmailLoginHandler(emailLoginService emailLoginService) *EmailLoginHandler {
+	return &EmailLoginHandler{emailLoginService: emailLoginService}
+}
+
+// RequestLink godoc
+//
+//	@Summary		Request an email login link
+//	@Description	Email a one-time login link and 6-digit code, bound to the requesting device
+//	@ID				user-email-link
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params					body		requests.EmailLinkRequest	true	"Email address and device ID"
+//	@Param			X-Challenge				header		string						false	"Proof-of-work challenge from GET /challenge"
+//	@Param			X-Challenge-Solution	header		string						false	"Proof-of-work solution"
+//	@Param			X-Captcha-Token			header		string						false	"Captcha response token, accepted instead of proof-of-work when configured"
+//	@Success		202						{object}	responses.Data
+//	@Failure		400						{object}	responses.Problem
+//	@Failure		403						{object}	responses.Problem
+//	@Failure		429						{object}	responses.Problem
+//	@Router			/login/email-link [post]
+func (h *EmailLoginHandler) RequestLink(c echo.Context) error {
+	var request requests.EmailLinkRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	if err := h.emailLoginService.RequestLogin(c.Request().Context(), &request); err != nil {
+		return err
+	}
+
+	return commonResponses.MessageResponse(c, http.StatusAccepted, "Login link sent")
+}
+
+// VerifyLink godoc
+//
+//	@Summary		Verify an email login link or code
+//	@Description	Exchange the emailed link token, or the email address and code, for tokens. Creates the user on first login.
+//	@ID				user-email-link-verify
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.EmailLinkVerifyRequest	true	"Link token, or email address and code"
+//	@Success		200		{object}	responses.LoginResponse
+//	@Failure		400		{object}	responses.Problem
+//	@Failure		401		{object}	responses.Problem
+//	@Failure		403		{object}	responses.Problem
+//	@Router			/login/email-link/verify [post]
+func (h *EmailLoginHandler) VerifyLink(c echo.Context) error {
+	var request requests.EmailLinkVerifyRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	response, err := h.emailLoginService.VerifyLogin(c.Request().Context(), &request)
+	if err != nil {
+		return err
+	}
+
+	return commonResponses.Response(c, http.StatusOK, response)
+}
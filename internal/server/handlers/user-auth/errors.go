@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/problem"
+)
+
+var (
+	errInvalidUserID           = problem.New(http.StatusBadRequest, "invalid_user_id", "Invalid user ID")
+	errInvalidVerificationCode = problem.New(http.StatusBadRequest, "invalid_verification_code", "Invalid or expired verification code")
+)
+
+// tokenUserError answers a missing user as an invalid token on routes that act on the user of the
+// access token: the user was deleted after the token was issued.
+func tokenUserError(err error) error {
+	if errors.Is(err, models.ErrUserNotFound) {
+		return models.ErrInvalidAuthToken
+	}
+
+	return err
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	commonResponses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/responses"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+	"github.com/google/uuid"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=impersonation_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type impersonator interface {
+	Impersonate(ctx context.Context, adminID, targetID uuid.UUID) (*responses.ImpersonationResponse, error)
+}
+
+type ImpersonationHandler struct {
+	impersonator impersonator
+}
+
+func NewImpersonationHandler(impersonator impersonator) *ImpersonationHandler {
+	return &ImpersonationHandler{impersonator: impersonator}
+}
+
+// Impersonate godoc
+//
+//	@Summary		Impersonate a user
+//	@Description	Issue a short-lived, non-refreshable access token for acting as the user. The token carries the admin in its act claim and cannot change the password, phone number or use admin endpoints. Admin only
+//	@ID				admin-user-impersonate
+//	@Tags			Admin Actions
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	responses.ImpersonationResponse
+//	@Failure		400	{object}	responses.Problem
+//	@Failure		403	{object}	responses.Problem
+//	@Failure		404	{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/admin/users/{id}/impersonate [post]
+func (h *ImpersonationHandler) Impersonate(c echo.Context) error {
+	claims, ok := middleware.UserClaims(c)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errInvalidUserID.Wrap(err)
+	}
+
+	response, err := h.impersonator.Impersonate(c.Request().Context(), claims.ID, targetID)
+	if err != nil {
+		return err
+	}
+
+	return commonResponses.Response(c, http.StatusOK, response)
+}
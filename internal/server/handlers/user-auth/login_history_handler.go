@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	commonResponses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/responses"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+	"github.com/google/uuid"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=login_history_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type loginHistoryService interface {
+	ListByUserID(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]models.LoginEvent, int64, error)
+}
+
+type LoginHistoryHandler struct {
+	loginHistoryService loginHistoryService
+}
+
+func NewLoginHistoryHandler(loginHistoryService loginHistoryService) *LoginHistoryHandler {
+	return &LoginHistoryHandler{loginHistoryService: loginHistoryService}
+}
+
+// ListMine godoc
+//
+//	@Summary		List own login history
+//	@Description	Page through the authenticated user's login attempts, newest first
+//	@ID				user-login-history
+//	@Tags			User Actions
+//	@Produce		json
+//	@Param			page		query		int	false	"Page number"	default(1)
+//	@Param			pageSize	query		int	false	"Page size"		default(20)
+//	@Success		200			{object}	responses.LoginEventsResponse
+//	@Failure		400			{object}	responses.Problem
+//	@Failure		401			{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/me/logins [get]
+func (h *LoginHistoryHandler) ListMine(c echo.Context) error {
+	claims, ok := middleware.UserClaims(c)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	return h.list(c, claims.ID)
+}
+
+// ListByUser godoc
+//
+//	@Summary		List a user's login history
+//	@Description	Page through the login attempts of any user, newest first. Admin only
+//	@ID				admin-user-login-history
+//	@Tags			Admin Actions
+//	@Produce		json
+//	@Param			id			path		string	true	"User ID"
+//	@Param			page		query		int		false	"Page number"	default(1)
+//	@Param			pageSize	query		int		false	"Page size"		default(20)
+//	@Success		200			{object}	responses.LoginEventsResponse
+//	@Failure		400			{object}	responses.Problem
+//	@Failure		403			{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/admin/users/{id}/logins [get]
+func (h *LoginHistoryHandler) ListByUser(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errInvalidUserID.Wrap(err)
+	}
+
+	return h.list(c, userID)
+}
+
+func (h *LoginHistoryHandler) list(c echo.Context, userID uuid.UUID) error {
+	request := requests.NewPageRequest()
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	events, total, err := h.loginHistoryService.ListByUserID(c.Request().Context(), userID, request.Page, request.PageSize)
+	if err != nil {
+		return err
+	}
+
+	return commonResponses.Response(c, http.StatusOK, responses.NewLoginEventsResponse(events, request.Page, request.PageSize, total))
+}
@@ -34,22 +34,23 @@ func NewOAuthHandler(userService userAuthenticator) *OAuthHandler {
 //	@Produce		json
 //	@Param			params	body		requests.OAuthRequest	true	"Google Token"
 //	@Success		200		{object}	responses.LoginResponse
-//	@Failure		401		{object}	responses.Error
+//	@Failure		401		{object}	responses.Problem
+//	@Failure		403		{object}	responses.Problem
 //	@Router			/google-oauth [post]
 func (oa *OAuthHandler) GoogleOAuth(c echo.Context) error {
 	var oAuthRequest requests.OAuthRequest
 
 	if err := c.Bind(&oAuthRequest); err != nil {
-		return commonResponses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request")
+		return err
 	}
 
 	if err := oAuthRequest.Validate(); err != nil {
-		return commonResponses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or invalid")
+		return err
 	}
 
 	accessToken, refreshToken, exp, err := oa.userService.GoogleOAuth(c.Request().Context(), oAuthRequest.Token)
 	if err != nil {
-		return commonResponses.ErrorResponse(c, http.StatusBadRequest, "Failed to authenticate with Google: "+err.Error())
+		return err
 	}
 
 	res := responses.NewLoginResponse(accessToken, refreshToken, exp)
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	commonResponses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+	"github.com/google/uuid"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=password_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type passwordChanger interface {
+	ChangePassword(ctx context.Context, id uuid.UUID, currentPassword, newPassword string) error
+}
+
+type PasswordHandler struct {
+	passwordChanger passwordChanger
+}
+
+func NewPasswordHandler(passwordChanger passwordChanger) *PasswordHandler {
+	return &PasswordHandler{passwordChanger: passwordChanger}
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change password
+//	@Description	Replace the authenticated user's password after confirming the current one
+//	@ID				user-change-password
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.ChangePasswordRequest	true	"Current and new password"
+//	@Success		200		{object}	responses.Data
+//	@Failure		400		{object}	responses.Problem{violations=[]policy.Violation}
+//	@Failure		401		{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/me/password [post]
+func (h *PasswordHandler) ChangePassword(c echo.Context) error {
+	claims, ok := middleware.UserClaims(c)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var request requests.ChangePasswordRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	err := h.passwordChanger.ChangePassword(c.Request().Context(), claims.ID, request.CurrentPassword, request.NewPassword)
+	if err != nil {
+		return tokenUserError(err)
+	}
+
+	return commonResponses.MessageResponse(c, http.StatusOK, "Password changed")
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	commonResponses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/responses"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+	"github.com/google/uuid"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=phone_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type phoneLoginService interface {
+	RequestVerification(ctx context.Context, userID uuid.UUID, request *requests.PhoneRequest) error
+	Verify(ctx context.Context, userID uuid.UUID, request *requests.PhoneVerifyRequest) error
+	RequestLogin(ctx context.Context, request *requests.PhoneRequest) error
+	VerifyLogin(ctx context.Context, request *requests.PhoneVerifyRequest) (*responses.LoginResponse, error)
+}
+
+type PhoneHandler struct {
+	phoneLoginService phoneLoginService
+}
+
+func NewPhoneHandler(phoneLoginService phoneLoginService) *PhoneHandler {
+	return &PhoneHandler{phoneLoginService: phoneLoginService}
+}
+
+// RequestVerification godoc
+//
+//	@Summary		Request phone verification
+//	@Description	Send an SMS code proving that the authenticated user owns the phone number
+//	@ID				user-phone
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params					body		requests.PhoneRequest	true	"Phone number and device ID"
+//	@Param			X-Challenge				header		string					false	"Proof-of-work challenge from GET /challenge"
+//	@Param			X-Challenge-Solution	header		string					false	"Proof-of-work solution"
+//	@Param			X-Captcha-Token			header		string					false	"Captcha response token, accepted instead of proof-of-work when configured"
+//	@Success		202						{object}	responses.Data
+//	@Failure		400						{object}	responses.Problem
+//	@Failure		401						{object}	responses.Problem
+//	@Failure		403						{object}	responses.Problem
+//	@Failure		409						{object}	responses.Problem
+//	@Failure		429						{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/me/phone [post]
+func (h *PhoneHandler) RequestVerification(c echo.Context) error {
+	claims, ok := middleware.UserClaims(c)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var request requests.PhoneRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	err := h.phoneLoginService.RequestVerification(c.Request().Context(), claims.ID, &request)
+	if err != nil {
+		return tokenUserError(err)
+	}
+
+	return commonResponses.MessageResponse(c, http.StatusAccepted, "Verification code sent")
+}
+
+// Verify godoc
+//
+//	@Summary		Verify phone number
+//	@Description	Store the phone number as the authenticated user's verified number if the SMS code matches
+//	@ID				user-phone-verify
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.PhoneVerifyRequest	true	"Phone number, code and device ID"
+//	@Success		200		{object}	responses.Data
+//	@Failure		400		{object}	responses.Problem
+//	@Failure		401		{object}	responses.Problem
+//	@Failure		409		{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/me/phone/verify [post]
+func (h *PhoneHandler) Verify(c echo.Context) error {
+	claims, ok := middleware.UserClaims(c)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	var request requests.PhoneVerifyRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	err := h.phoneLoginService.Verify(c.Request().Context(), claims.ID, &request)
+	if errors.Is(err, models.ErrInvalidOneTimeCode) {
+		// Not 401, which would tell the client that its access token is no longer valid
+		return errInvalidVerificationCode.Wrap(err)
+	} else if err != nil {
+		return tokenUserError(err)
+	}
+
+	return commonResponses.MessageResponse(c, http.StatusOK, "Phone number verified")
+}
+
+// RequestLogin godoc
+//
+//	@Summary		Request a phone login code
+//	@Description	Send an SMS login code to a verified phone number. Responds the same way whether or not the number is known.
+//	@ID				user-phone-login
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params					body		requests.PhoneRequest	true	"Phone number and device ID"
+//	@Param			X-Challenge				header		string					false	"Proof-of-work challenge from GET /challenge"
+//	@Param			X-Challenge-Solution	header		string					false	"Proof-of-work solution"
+//	@Param			X-Captcha-Token			header		string					false	"Captcha response token, accepted instead of proof-of-work when configured"
+//	@Success		202						{object}	responses.Data
+//	@Failure		400						{object}	responses.Problem
+//	@Failure		403						{object}	responses.Problem
+//	@Failure		429						{object}	responses.Problem
+//	@Router			/login/phone [post]
+func (h *PhoneHandler) RequestLogin(c echo.Context) error {
+	var request requests.PhoneRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	if err := h.phoneLoginService.RequestLogin(c.Request().Context(), &request); err != nil {
+		return err
+	}
+
+	return commonResponses.MessageResponse(c, http.StatusAccepted, "Login code sent")
+}
+
+// VerifyLogin godoc
+//
+//	@Summary		Verify a phone login code
+//	@Description	Exchange the phone number and SMS code for tokens
+//	@ID				user-phone-login-verify
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.PhoneVerifyRequest	true	"Phone number, code and device ID"
+//	@Success		200		{object}	responses.LoginResponse
+//	@Failure		400		{object}	responses.Problem
+//	@Failure		401		{object}	responses.Problem
+//	@Failure		403		{object}	responses.Problem
+//	@Router			/login/phone/verify [post]
+func (h *PhoneHandler) VerifyLogin(c echo.Context) error {
+	var request requests.PhoneVerifyRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	response, err := h.phoneLoginService.VerifyLogin(c.Request().Context(), &request)
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		// Do not tell whether the number belongs to a user
+		return models.ErrInvalidOneTimeCode
+	case err != nil:
+		return err
+	}
+
+	return commonResponses.Response(c, http.StatusOK, response)
+}
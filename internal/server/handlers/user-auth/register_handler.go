@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 
 	responses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
@@ -35,29 +36,34 @@ func NewRegisterHandler(userRegisterer userRegisterer) *RegisterHandler {
 //	@Tags			User Actions
 //	@Accept			json
 //	@Produce		json
-//	@Param			params	body		requests.RegisterRequest	true	"User's email, user's password"
-//	@Success		201		{object}	responses.Data
-//	@Failure		400		{object}	responses.Error
+//	@Param			params					body		requests.RegisterRequest	true	"User's email, user's password"
+//	@Param			X-Challenge				header		string						false	"Proof-of-work challenge from GET /challenge"
+//	@Param			X-Challenge-Solution	header		string						false	"Proof-of-work solution"
+//	@Param			X-Captcha-Token			header		string						false	"Captcha response token, accepted instead of proof-of-work when configured"
+//	@Success		201						{object}	responses.Data
+//	@Failure		400						{object}	responses.Problem{violations=[]policy.Violation}
+//	@Failure		403						{object}	responses.Problem
+//	@Failure		409						{object}	responses.Problem
 //	@Router			/register [post]
 func (h *RegisterHandler) Register(c echo.Context) error {
 	var registerRequest requests.RegisterRequest
 	if err := c.Bind(&registerRequest); err != nil {
-		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request")
+		return err
 	}
 
 	if err := registerRequest.Validate(); err != nil {
-		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or invalid")
+		return err
 	}
 
 	_, err := h.userRegisterer.GetUserByEmail(c.Request().Context(), registerRequest.Email)
 	if err == nil {
-		return responses.ErrorResponse(c, http.StatusConflict, "User already exists")
+		return models.ErrUserExists
 	} else if !errors.Is(err, models.ErrUserNotFound) {
-		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check if user exists")
+		return fmt.Errorf("check if user exists: %w", err)
 	}
 
 	if err := h.userRegisterer.Register(c.Request().Context(), &registerRequest); err != nil {
-		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to register user")
+		return err
 	}
 
 	return responses.MessageResponse(c, http.StatusCreated, "User successfully created")
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	commonResponses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
+	"github.com/google/uuid"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=user_admin_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type userAdministrator interface {
+	Ban(ctx context.Context, id uuid.UUID) error
+	Unban(ctx context.Context, id uuid.UUID) error
+	ChangeRole(ctx context.Context, id uuid.UUID, role string) error
+}
+
+type UserAdminHandler struct {
+	userAdministrator userAdministrator
+}
+
+func NewUserAdminHandler(userAdministrator userAdministrator) *UserAdminHandler {
+	return &UserAdminHandler{userAdministrator: userAdministrator}
+}
+
+// Ban godoc
+//
+//	@Summary		Ban a user
+//	@Description	Prevent a user from logging in or refreshing tokens. Admin only
+//	@ID				admin-user-ban
+//	@Tags			Admin Actions
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	responses.Data
+//	@Failure		404	{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/admin/users/{id}/ban [post]
+func (h *UserAdminHandler) Ban(c echo.Context) error {
+	return h.update(c, "User banned", h.userAdministrator.Ban)
+}
+
+// Unban godoc
+//
+//	@Summary		Unban a user
+//	@Description	Restore a banned user's access. Admin only
+//	@ID				admin-user-unban
+//	@Tags			Admin Actions
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	responses.Data
+//	@Failure		404	{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/admin/users/{id}/unban [post]
+func (h *UserAdminHandler) Unban(c echo.Context) error {
+	return h.update(c, "User unbanned", h.userAdministrator.Unban)
+}
+
+// ChangeRole godoc
+//
+//	@Summary		Change a user's role
+//	@Description	Assign a new role to a user. Admin only
+//	@ID				admin-user-role
+//	@Tags			Admin Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"User ID"
+//	@Param			params	body		requests.ChangeRoleRequest	true	"New role"
+//	@Success		200		{object}	responses.Data
+//	@Failure		400		{object}	responses.Problem
+//	@Failure		404		{object}	responses.Problem
+//	@Security		ApiKeyAuth
+//	@Router			/admin/users/{id}/role [put]
+func (h *UserAdminHandler) ChangeRole(c echo.Context) error {
+	var request requests.ChangeRoleRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	return h.update(c, "User role changed", func(ctx context.Context, id uuid.UUID) error {
+		return h.userAdministrator.ChangeRole(ctx, id, request.Role)
+	})
+}
+
+func (h *UserAdminHandler) update(c echo.Context, message string, apply func(ctx context.Context, id uuid.UUID) error) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errInvalidUserID.Wrap(err)
+	}
+
+	if err := apply(c.Request().Context(), userID); err != nil {
+		return err
+	}
+
+	return commonResponses.MessageResponse(c, http.StatusOK, message)
+}
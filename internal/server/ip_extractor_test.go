@@ -0,0 +1,49 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIPExtractor(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		want           string
+	}{
+		{name: "untrusted headers", remoteAddr: "203.0.113.7:4000", want: "203.0.113.7"},
+		{name: "private peer is not trusted by default", trustedProxies: []string{"10.1.0.0/16"}, remoteAddr: "10.2.0.1:4000", want: "10.2.0.1"},
+		{name: "trusted proxy", trustedProxies: []string{"10.1.0.0/16"}, remoteAddr: "10.1.0.5:4000", want: "198.51.100.2"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			trustedProxies, err := server.ParseTrustedProxies(tt.trustedProxies)
+			require.NoError(t, err)
+
+			extractor := server.NewIPExtractor(trustedProxies)
+
+			request, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "/", http.NoBody)
+			require.NoError(t, err)
+			request.RemoteAddr = tt.remoteAddr
+			request.Header.Set("X-Forwarded-For", "192.0.2.1, 198.51.100.2")
+			request.Header.Set("X-Real-IP", "192.0.2.1")
+
+			assert.Equal(t, tt.want, extractor(request))
+		})
+	}
+}
+
+func TestParseTrustedProxiesInvalidRange(t *testing.T) {
+	t.Parallel()
+
+	_, err := server.ParseTrustedProxies([]string{"10.0.0.1"})
+	require.Error(t, err)
+}
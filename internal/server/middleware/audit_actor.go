@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewAuditActor stores the authenticated user as the audit actor of the request. With an
+// impersonation token the admin is the actor. It must be mounted after the JWT middleware.
+func NewAuditActor() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if claims, ok := UserClaims(c); ok {
+				actorID := claims.ID
+				if claims.Impersonated() {
+					actorID = claims.Act.Subject
+				}
+
+				ctx := audit.WithActor(c.Request().Context(), audit.UserActor(actorID))
+				c.SetRequest(c.Request().WithContext(ctx))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/challenge"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderClientKey         = "X-Client-Key"
+	HeaderCaptchaToken      = "X-Captcha-Token"
+	HeaderChallenge         = "X-Challenge"
+	HeaderChallengeSolution = "X-Challenge-Solution"
+)
+
+// ChallengeGuardConfig configures [NewChallengeGuard]. CaptchaVerifier and Allowlist are optional.
+type ChallengeGuardConfig struct {
+	ProofOfWork     *challenge.ProofOfWork
+	CaptchaVerifier challenge.CaptchaVerifier
+	Allowlist       *challenge.Allowlist
+}
+
+// NewChallengeGuard allows the request only if the client is allowlisted, passes the captcha in
+// the X-Captcha-Token header, or solved the proof-of-work challenge in the X-Challenge headers.
+func NewChallengeGuard(config ChallengeGuardConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+			ctx := request.Context()
+
+			if config.Allowlist != nil && config.Allowlist.Allows(c.RealIP(), request.Header.Get(HeaderClientKey)) {
+				return next(c)
+			}
+
+			var err error
+			switch {
+			case config.CaptchaVerifier != nil && request.Header.Get(HeaderCaptchaToken) != "":
+				err = config.CaptchaVerifier.Verify(ctx, request.Header.Get(HeaderCaptchaToken), c.RealIP())
+			case request.Header.Get(HeaderChallenge) != "":
+				err = config.ProofOfWork.Verify(request.Header.Get(HeaderChallenge), request.Header.Get(HeaderChallengeSolution))
+			default:
+				err = challenge.ErrChallengeRequired
+			}
+
+			switch {
+			case errors.Is(err, challenge.ErrChallengeRequired):
+				return err
+			case errors.Is(err, challenge.ErrChallengeFailed):
+				slog.InfoContext(ctx, "Challenge failed", "err", err.Error())
+
+				return err
+			case err != nil:
+				return fmt.Errorf("verify challenge: %w", err)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/clientinfo"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderClientID names the calling app, e.g. "web" or "ios/2.3.1". It is only used in logs.
+const HeaderClientID = "X-Client-ID"
+
+// NewClientInfo stores the caller's IP address, user agent and client ID in the request context.
+// Client IDs that are not safe to log are ignored.
+func NewClientInfo() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			clientID := c.Request().Header.Get(HeaderClientID)
+			if !slogx.ValidRequestID(clientID) {
+				clientID = ""
+			}
+
+			ctx := clientinfo.WithInfo(c.Request().Context(), clientinfo.Info{
+				IP:        c.RealIP(),
+				UserAgent: c.Request().UserAgent(),
+				ClientID:  clientID,
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/labstack/echo/v4"
+)
+
+type debugTokenVerifier interface {
+	Verify(token string) error
+}
+
+// NewDebugEscalation logs a request at DEBUG level, whatever the configured levels, when it carries
+// a valid X-Debug-Token header. Without verifier, the header is ignored.
+func NewDebugEscalation(verifier debugTokenVerifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if verifier == nil {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			token := c.Request().Header.Get(slogx.DebugTokenHeader)
+			if token == "" {
+				return next(c)
+			}
+
+			request := c.Request()
+			if err := verifier.Verify(token); err != nil {
+				slog.WarnContext(request.Context(), "Debug token rejected", "err", err.Error())
+
+				return next(c)
+			}
+
+			c.SetRequest(request.WithContext(slogx.WithDebug(request.Context())))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/problem"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewImpersonationLogger logs every request made with an impersonation token with both the
+// impersonated user and the admin behind it. It must be mounted after the JWT and request attributes
+// middlewares.
+func NewImpersonationLogger() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := UserClaims(c)
+			if !ok || !claims.Impersonated() {
+				return next(c)
+			}
+
+			err := next(c)
+
+			// The user and the admin are attached by the request attributes middleware
+			slog.InfoContext(c.Request().Context(), "Impersonated request",
+				slog.Group("http",
+					"method", c.Request().Method,
+					"status", c.Response().Status,
+					"path", c.Path(),
+				),
+			)
+
+			return err
+		}
+	}
+}
+
+var errImpersonating = problem.New(http.StatusForbidden, "impersonation_not_allowed", "Not allowed while impersonating")
+
+// NewImpersonationGuard rejects requests made with an impersonation token. Mount it on sensitive
+// routes such as credential changes. It must be mounted after the JWT middleware.
+func NewImpersonationGuard() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if claims, ok := UserClaims(c); ok && claims.Impersonated() {
+				return errImpersonating
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImpersonationGuard(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name    string
+		act     *token.ActorClaim
+		allowed bool
+	}{
+		{name: "user token", allowed: true},
+		{name: "impersonation token", act: &token.ActorClaim{Subject: uuid.New()}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/me/password", http.NoBody)
+			c := echo.New().NewContext(request, httptest.NewRecorder())
+			c.Set("user", &jwt.Token{Claims: &token.JwtCustomClaims{ID: uuid.New(), Act: tt.act}})
+
+			called := false
+			err := middleware.NewImpersonationGuard()(func(echo.Context) error {
+				called = true
+
+				return nil
+			})(c)
+
+			assert.Equal(t, tt.allowed, called)
+
+			if tt.allowed {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// unmatchedRoute labels requests that matched no route, so that scanners cannot create a label per path.
+const unmatchedRoute = "unmatched"
+
+// NewMetrics counts requests and measures their latency by route template, as in [NewRequestLogger].
+func NewMetrics(registerer prometheus.Registerer) echo.MiddlewareFunc {
+	factory := promauto.With(registerer)
+
+	requests := factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	duration := factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			errNext := next(c)
+			if errNext != nil {
+				// Write the error response now to measure its status.
+				c.Error(errNext)
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			method := c.Request().Method
+			requests.WithLabelValues(method, route, strconv.Itoa(c.Response().Status)).Inc()
+			duration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+
+			if errNext != nil {
+				return fmt.Errorf("handle request with metrics: %w", errNext)
+			}
+
+			return nil
+		}
+	}
+}
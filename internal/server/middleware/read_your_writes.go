@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LastWriteCookie holds the time of the client's last database write in Unix milliseconds.
+const LastWriteCookie = "db_last_write"
+
+// NewReadYourWrites starts a database session for each request, so that reads go to the primary
+// after a write. The time of the last write is kept in a cookie for window, which pins the next
+// requests of the client too, whichever instance serves them.
+func NewReadYourWrites(window time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var lastWrite time.Time
+			if cookie, err := c.Cookie(LastWriteCookie); err == nil {
+				// A time in the future would pin the client forever.
+				if millis, err := strconv.ParseInt(cookie.Value, 10, 64); err == nil && millis <= time.Now().UnixMilli() {
+					lastWrite = time.UnixMilli(millis)
+				}
+			}
+
+			ctx, session := db.WithSession(c.Request().Context(), lastWrite)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			c.Response().Before(func() {
+				written := session.LastWrite()
+				if window <= 0 || !written.After(lastWrite) {
+					return
+				}
+
+				c.SetCookie(&http.Cookie{
+					Name:     LastWriteCookie,
+					Value:    strconv.FormatInt(written.UnixMilli(), 10),
+					Path:     "/",
+					MaxAge:   int(math.Ceil(window.Seconds())),
+					Secure:   c.Scheme() == "https",
+					HttpOnly: true,
+					SameSite: http.SameSiteLaxMode,
+				})
+			})
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/clientinfo"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewRequestAttributes adds the authenticated user, the session, the client ID and the IP address
+// to every log record of the request. With an impersonation token the admin is logged as actor_id.
+// It must be mounted after the JWT and client info middlewares.
+func NewRequestAttributes() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			var args []any
+
+			if claims, ok := UserClaims(c); ok {
+				args = append(args, "user_id", claims.ID.String())
+
+				if claims.Impersonated() {
+					args = append(args, "actor_id", claims.Act.Subject.String())
+				}
+
+				if claims.SessionID != "" {
+					args = append(args, "session_id", claims.SessionID)
+				}
+			}
+
+			info := clientinfo.FromContext(ctx)
+			if info.ClientID != "" {
+				args = append(args, "client_id", info.ClientID)
+			}
+
+			if info.IP != "" {
+				args = append(args, "ip", info.IP)
+			}
+
+			if len(args) > 0 {
+				c.SetRequest(c.Request().WithContext(slogx.With(ctx, args...)))
+			}
+
+			return next(c)
+		}
+	}
+}
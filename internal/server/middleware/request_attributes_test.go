@@ -0,0 +1,84 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestAttributes(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	adminID := uuid.New()
+
+	for _, tt := range []struct {
+		name   string
+		claims *token.JwtCustomClaims
+		want   map[string]string
+	}{
+		{
+			name: "anonymous",
+			want: map[string]string{"client_id": "web", "ip": "203.0.113.7"},
+		},
+		{
+			name:   "user",
+			claims: &token.JwtCustomClaims{ID: userID, SessionID: "session-1"},
+			want: map[string]string{
+				"user_id":    userID.String(),
+				"session_id": "session-1",
+				"client_id":  "web",
+				"ip":         "203.0.113.7",
+			},
+		},
+		{
+			name:   "impersonation",
+			claims: &token.JwtCustomClaims{ID: userID, Act: &token.ActorClaim{Subject: adminID}},
+			want: map[string]string{
+				"user_id":   userID.String(),
+				"actor_id":  adminID.String(),
+				"client_id": "web",
+				"ip":        "203.0.113.7",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", http.NoBody)
+			request.RemoteAddr = "203.0.113.7:4000"
+			request.Header.Set(middleware.HeaderClientID, "web")
+
+			c := echo.New().NewContext(request, httptest.NewRecorder())
+			if tt.claims != nil {
+				c.Set("user", &jwt.Token{Claims: tt.claims})
+			}
+
+			var ctx context.Context
+
+			handler := middleware.NewClientInfo()(middleware.NewRequestAttributes()(func(c echo.Context) error {
+				ctx = c.Request().Context()
+
+				return nil
+			}))
+			require.NoError(t, handler(c))
+
+			got := make(map[string]string)
+			for _, attr := range slogx.Attrs(ctx) {
+				got[attr.Key] = attr.Value.String()
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
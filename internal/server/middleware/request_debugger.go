@@ -2,35 +2,70 @@
 package middleware
 
 import (
+	"bufio"
 	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"slices"
 	"strings"
 
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/redact"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	truncatedMarker = "...[TRUNCATED]"
+	mimeEventStream = "text/event-stream"
+)
+
+type RequestDebuggerConfig struct {
+	// MaxBodySize is the number of bytes of each body that is logged.
+	MaxBodySize int
+	// SampleRatio is the fraction in [0, 1] of requests that are logged.
+	SampleRatio float64
+	// Routes are the route templates whose requests are logged, all if empty.
+	Routes []string
+}
+
 // requestDebugger is a logging middleware that logs request and response bodies with DEBUG level logs.
-// Warning: Do not use this middleware with endpoints containing sensitive information.
+// Secrets are redacted from the query string and the bodies before logging.
+//
+// Only the first MaxBodySize bytes of each body are kept in memory and logged, and streamed
+// responses, which are flushed or sent as server-sent events, are not logged at all. Requests are
+// sampled, or forced with a valid X-Debug-Token header, which only admins can issue.
 //
 // Based on the Content-Type, it determines how the body will be formatted.
 // If the content type is application/json, the body will be logged as JSON; otherwise, it will be logged as a string.
-type requestDebugger struct{}
+type requestDebugger struct {
+	redactor *redact.Redactor
+	// requestRedactor also redacts the one-time codes sent in request bodies
+	requestRedactor *redact.Redactor
+	config          RequestDebuggerConfig
+	random          func() float64
+}
 
-func NewRequestDebugger() echo.MiddlewareFunc {
-	return (&requestDebugger{}).handle
+func NewRequestDebugger(redactor *redact.Redactor, config RequestDebuggerConfig) echo.MiddlewareFunc {
+	return (&requestDebugger{
+		redactor:        redactor,
+		requestRedactor: redactor.With(redact.OneTimeCodeKeys...),
+		config:          config,
+		random:          rand.Float64,
+	}).handle
 }
 
 func (d *requestDebugger) handle(next echo.HandlerFunc) echo.HandlerFunc {
-	if !slog.Default().Enabled(context.Background(), slog.LevelDebug) {
-		return next
-	}
-
 	return func(c echo.Context) error {
+		// The level may change at runtime or be raised for this request.
+		if !slog.Default().Enabled(c.Request().Context(), slog.LevelDebug) || !d.capture(c) {
+			return next(c)
+		}
+
 		requestBody, err := d.getRequestBody(c)
 		if err != nil {
 			return fmt.Errorf("get request body for logging: %w", err)
@@ -39,8 +74,16 @@ func (d *requestDebugger) handle(next echo.HandlerFunc) echo.HandlerFunc {
 		responseBodyGetter := d.getResponseBodyGetter(c)
 
 		errNext := next(c)
+		if errNext != nil {
+			// Write the error response now to capture it.
+			c.Error(errNext)
+		}
 
 		var attrs []any
+		if query := c.Request().URL.RawQuery; query != "" {
+			attrs = append(attrs, "query", d.requestRedactor.RawQuery(query))
+		}
+
 		if requestBody != nil {
 			attrs = append(attrs, "request_body", requestBody)
 		}
@@ -65,59 +108,123 @@ func (d *requestDebugger) handle(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
+// capture decides whether the bodies of the request are logged.
+func (d *requestDebugger) capture(c echo.Context) bool {
+	if len(d.config.Routes) > 0 && !slices.Contains(d.config.Routes, c.Path()) {
+		return false
+	}
+
+	if slogx.IsDebug(c.Request().Context()) {
+		return true
+	}
+
+	return d.random() < d.config.SampleRatio
+}
+
 func (d *requestDebugger) getRequestBody(c echo.Context) (any, error) {
-	if c.Request().Body == nil {
+	request := c.Request()
+	if request.Body == nil || request.Body == http.NoBody {
 		return nil, nil
 	}
 
-	rawRequestBody, err := io.ReadAll(c.Request().Body)
+	// Read one byte more than logged to know whether the body is truncated.
+	rawRequestBody, err := io.ReadAll(io.LimitReader(request.Body, int64(d.config.MaxBodySize)+1))
 	if err != nil {
 		return nil, fmt.Errorf("read request body: %w", err)
 	}
 
-	request := c.Request()
-	request.Body = io.NopCloser(bytes.NewReader(rawRequestBody))
+	// The handler reads what was captured, then the rest of the original body.
+	request.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(rawRequestBody), request.Body),
+		Closer: request.Body,
+	}
 	c.SetRequest(request)
 
-	if strings.HasPrefix(request.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
-		return json.RawMessage(rawRequestBody), nil
+	truncated := len(rawRequestBody) > d.config.MaxBodySize
+	if truncated {
+		rawRequestBody = rawRequestBody[:d.config.MaxBodySize]
 	}
 
-	return string(rawRequestBody), nil
+	return formatBody(d.requestRedactor, request.Header.Get(echo.HeaderContentType), rawRequestBody, truncated), nil
 }
 
 func (d *requestDebugger) getResponseBodyGetter(c echo.Context) func(echo.Context) any {
 	response := c.Response()
-	storer := newResponseStorer(response.Writer)
+	storer := newResponseStorer(response.Writer, d.config.MaxBodySize)
 	response.Writer = storer
 	c.SetResponse(response)
 
 	return func(c echo.Context) any {
-		if storer.storedResponse == nil {
+		contentType := c.Response().Header().Get(echo.HeaderContentType)
+		if storer.flushed || strings.HasPrefix(contentType, mimeEventStream) {
+			return "[STREAMED]"
+		}
+
+		if storer.size == 0 {
 			return nil
 		}
 
-		if strings.HasPrefix(c.Response().Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
-			return json.RawMessage(storer.storedResponse)
+		return formatBody(d.redactor, contentType, storer.storedResponse, storer.size > len(storer.storedResponse))
+	}
+}
+
+// formatBody redacts the body and formats it by its content type. A truncated body cannot be
+// valid JSON, so it is logged as a string ending with a marker.
+func formatBody(redactor *redact.Redactor, contentType string, body []byte, truncated bool) any {
+	var formatted string
+
+	switch {
+	case strings.HasPrefix(contentType, echo.MIMEApplicationJSON):
+		if !truncated {
+			return json.RawMessage(redactor.JSON(body))
 		}
 
-		return string(storer.storedResponse)
+		formatted = string(redactor.JSON(body))
+	case strings.HasPrefix(contentType, echo.MIMEApplicationForm):
+		formatted = redactor.RawQuery(string(body))
+	default:
+		formatted = redactor.String(string(body))
 	}
+
+	if truncated {
+		formatted += truncatedMarker
+	}
+
+	return formatted
 }
 
-// responseStorer stores the written response by the handler into its field.
-// This is used to automate response logging.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+var (
+	_ http.Flusher  = (*responseStorer)(nil)
+	_ http.Hijacker = (*responseStorer)(nil)
+)
+
+// responseStorer stores up to limit bytes of the response written by the handler, to automate
+// response logging. Capture stops once the response is flushed, as it is then streamed.
 type responseStorer struct {
 	http.ResponseWriter
 	storedResponse []byte
+	limit          int
+	size           int
+	flushed        bool
 }
 
-func newResponseStorer(writer http.ResponseWriter) *responseStorer {
-	return &responseStorer{ResponseWriter: writer}
+func newResponseStorer(writer http.ResponseWriter, limit int) *responseStorer {
+	return &responseStorer{ResponseWriter: writer, limit: limit}
 }
 
 func (s *responseStorer) Write(response []byte) (int, error) {
-	s.storedResponse = response
+	if !s.flushed {
+		s.size += len(response)
+
+		if room := s.limit - len(s.storedResponse); room > 0 {
+			s.storedResponse = append(s.storedResponse, response[:min(room, len(response))]...)
+		}
+	}
 
 	n, err := s.ResponseWriter.Write(response)
 	if err != nil {
@@ -126,3 +233,28 @@ func (s *responseStorer) Write(response []byte) (int, error) {
 
 	return n, nil
 }
+
+func (s *responseStorer) Flush() {
+	s.flushed = true
+	s.storedResponse = nil
+
+	if err := http.NewResponseController(s.ResponseWriter).Flush(); err != nil {
+		slog.Warn("Flush response", "err", err.Error())
+	}
+}
+
+func (s *responseStorer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	s.flushed = true
+
+	conn, rw, err := http.NewResponseController(s.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, fmt.Errorf("hijack response: %w", err)
+	}
+
+	return conn, rw, nil
+}
+
+// Unwrap lets [http.ResponseController] reach the underlying writer.
+func (s *responseStorer) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,49 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/redact"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Sets the default logger.
+func TestRequestDebuggerRedactsOneTimeCodes(t *testing.T) {
+	var logs bytes.Buffer
+
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	engine := echo.New()
+	engine.Use(middleware.NewRequestDebugger(redact.New(nil), middleware.RequestDebuggerConfig{
+		MaxBodySize: 1024,
+		SampleRatio: 1,
+	}))
+	engine.POST("/login/phone/verify", func(c echo.Context) error {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"code": "invalid_one_time_code"})
+	})
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/login/phone/verify",
+		strings.NewReader(`{"phone":"+84912345678","code":"123456"}`))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	engine.ServeHTTP(httptest.NewRecorder(), request)
+
+	var record struct {
+		RequestBody  map[string]string `json:"request_body"`
+		ResponseBody map[string]string `json:"response_body"`
+	}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &record))
+
+	assert.Equal(t, redact.Placeholder, record.RequestBody["code"])
+	assert.Equal(t, "invalid_one_time_code", record.ResponseBody["code"], "problem codes stay readable")
+}
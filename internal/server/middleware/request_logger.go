@@ -33,6 +33,10 @@ func (l *requestLogger) handle(next echo.HandlerFunc) echo.HandlerFunc {
 		c.SetRequest(c.Request().WithContext(ctx))
 
 		errNext := next(c)
+		if errNext != nil {
+			// Write the error response now to log its status.
+			c.Error(errNext)
+		}
 
 		level := slog.LevelInfo
 		if c.Response().Status >= http.StatusInternalServerError {
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// jwtContextKey is the key under which echo-jwt stores the parsed token.
+const jwtContextKey = "user"
+
+// UserClaims returns the access token claims of the authenticated user.
+// It must be called after the JWT middleware has run.
+func UserClaims(c echo.Context) (*token.JwtCustomClaims, bool) {
+	jwtToken, ok := c.Get(jwtContextKey).(*jwt.Token)
+	if !ok {
+		return nil, false
+	}
+
+	claims, ok := jwtToken.Claims.(*token.JwtCustomClaims)
+
+	return claims, ok
+}
+
+// NewRoleGuard allows the request only if the authenticated user has one of the given roles.
+func NewRoleGuard(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := UserClaims(c)
+			if !ok {
+				return echo.ErrUnauthorized
+			}
+
+			if !slices.Contains(roles, claims.Role) {
+				return echo.ErrForbidden
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+
+// clientRequestIDKey records the X-Request-ID of a caller that is not trusted.
+const clientRequestIDKey = attribute.Key("http.request.header.x-request-id")
+
+// NewTracing starts a server span per request, named after the route template. When the peer is
+// in trustedProxies it continues the trace of an inbound traceparent header and keeps a valid
+// inbound X-Request-ID; otherwise the trace ID serves as request ID. Other callers could force
+// sampling or join someone else's trace, so their span starts a new trace that links to the
+// inbound one and their request ID is only recorded as an attribute. The request ID is echoed in
+// the response. It must run before [NewRequestLogger], which logs the span's trace ID.
+func NewTracing(trustedProxies []*net.IPNet) echo.MiddlewareFunc {
+	httpTracer := otel.Tracer(tracerName)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			ctx := request.Context()
+			inbound := otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(request.Header))
+
+			options := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(request.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(request.URL.Path),
+					semconv.UserAgentOriginal(request.UserAgent()),
+				),
+			}
+
+			requestID := request.Header.Get(slogx.RequestIDHeader)
+			if !slogx.ValidRequestID(requestID) {
+				requestID = ""
+			}
+
+			if trustedPeer(request.RemoteAddr, trustedProxies) {
+				ctx = inbound
+
+				if requestID != "" {
+					// Also lets a root span take its trace ID from the request ID.
+					ctx = slogx.WithRequestID(ctx, requestID)
+				}
+			} else {
+				options = append(options, trace.WithNewRoot())
+
+				if remote := trace.SpanContextFromContext(inbound); remote.IsValid() {
+					options = append(options, trace.WithLinks(trace.Link{SpanContext: remote}))
+				}
+
+				if requestID != "" {
+					options = append(options, trace.WithAttributes(clientRequestIDKey.String(requestID)))
+					requestID = ""
+				}
+			}
+
+			ctx, span := httpTracer.Start(ctx, request.Method+" "+route, options...)
+			defer span.End()
+
+			if requestID == "" {
+				requestID = span.SpanContext().TraceID().String()
+				ctx = slogx.WithRequestID(ctx, requestID)
+			}
+
+			c.Response().Header().Set(slogx.RequestIDHeader, requestID)
+			c.SetRequest(request.WithContext(ctx))
+
+			errNext := next(c)
+			if errNext != nil {
+				// Write the error response now to record its status.
+				c.Error(errNext)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+
+			if errNext != nil {
+				span.RecordError(errNext)
+
+				return fmt.Errorf("handle request with tracing: %w", errNext)
+			}
+
+			return nil
+		}
+	}
+}
+
+// trustedPeer reports whether the peer address of a request is in one of the trusted ranges.
+func trustedPeer(remoteAddr string, trustedProxies []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, ipRange := range trustedProxies {
+		if ipRange.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
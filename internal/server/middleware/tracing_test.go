@@ -0,0 +1,88 @@
+package middleware_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	inboundTraceID   = "4bf92f3577b34da6a3ce929d0e0e4736"
+	inboundRequestID = "client-request-1"
+)
+
+//nolint:paralleltest // Sets the global tracer provider and propagator.
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	_, trusted, err := net.ParseCIDR("10.1.0.0/16")
+	require.NoError(t, err)
+
+	tracing := middleware.NewTracing([]*net.IPNet{trusted})
+
+	serve := func(remoteAddr string) *httptest.ResponseRecorder {
+		engine := echo.New()
+		engine.Use(tracing)
+		engine.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", http.NoBody)
+		request.RemoteAddr = remoteAddr
+		request.Header.Set("Traceparent", "00-"+inboundTraceID+"-00f067aa0ba902b7-01")
+		request.Header.Set(slogx.RequestIDHeader, inboundRequestID)
+
+		response := httptest.NewRecorder()
+		engine.ServeHTTP(response, request)
+
+		return response
+	}
+
+	t.Run("trusted proxy", func(t *testing.T) {
+		response := serve("10.1.0.5:4000")
+		assert.Equal(t, inboundRequestID, response.Header().Get(slogx.RequestIDHeader))
+
+		spans := recorder.Ended()
+		require.NotEmpty(t, spans)
+
+		span := spans[len(spans)-1]
+		assert.Equal(t, inboundTraceID, span.SpanContext().TraceID().String())
+		assert.Empty(t, span.Links())
+	})
+
+	t.Run("untrusted client", func(t *testing.T) {
+		response := serve("203.0.113.7:4000")
+
+		spans := recorder.Ended()
+		require.NotEmpty(t, spans)
+
+		span := spans[len(spans)-1]
+		traceID := span.SpanContext().TraceID().String()
+		assert.NotEqual(t, inboundTraceID, traceID)
+		assert.Equal(t, traceID, response.Header().Get(slogx.RequestIDHeader))
+
+		require.Len(t, span.Links(), 1)
+		assert.Equal(t, inboundTraceID, span.Links()[0].SpanContext.TraceID().String())
+
+		var clientRequestID string
+
+		for _, attr := range span.Attributes() {
+			if attr.Key == "http.request.header.x-request-id" {
+				clientRequestID = attr.Value.AsString()
+			}
+		}
+
+		assert.Equal(t, inboundRequestID, clientRequestID)
+	})
+}
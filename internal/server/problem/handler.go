@@ -0,0 +1,89 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	commonResponses "github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/common"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+// NewErrorHandler answers the errors of handlers and middlewares with problem details carrying the
+// trace ID. Errors are mapped in order: an [Error], a target of registry, failed validation, an
+// [echo.HTTPError] such as a bind failure, and anything else is an internal error whose cause is
+// not shown. The error itself is logged by the request logger.
+func NewErrorHandler(registry *Registry) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		ctx := c.Request().Context()
+
+		problem := registry.problem(err)
+		problem.Instance = c.Request().URL.Path
+
+		if traceID, ok := slogx.TraceID(ctx); ok {
+			problem.TraceID = traceID
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(problem.Status)
+		} else {
+			c.Response().Header().Set(echo.HeaderContentType, ContentType)
+			c.Response().WriteHeader(problem.Status)
+			err = json.NewEncoder(c.Response()).Encode(problem)
+		}
+
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to write problem", "err", err.Error())
+		}
+	}
+}
+
+func (r *Registry) problem(err error) commonResponses.Problem {
+	var (
+		problemErr       *Error
+		validationErrors validation.Errors
+		httpErr          *echo.HTTPError
+	)
+
+	var problem commonResponses.Problem
+
+	definition, registered := r.Lookup(err)
+
+	switch {
+	case errors.As(err, &problemErr):
+		problem = commonResponses.Problem{Status: problemErr.Status, Code: problemErr.Code, Title: problemErr.Title}
+	case registered:
+		problem = commonResponses.Problem{Status: definition.Status, Code: definition.Code, Title: definition.Title}
+
+		if definition.Extensions != nil {
+			problem.Extensions = definition.Extensions(err)
+		}
+	case errors.As(err, &validationErrors):
+		problem = commonResponses.Problem{Status: http.StatusBadRequest, Code: "invalid_request", Title: "Request is not valid"}
+
+		problem.Errors = make(map[string]string, len(validationErrors))
+		for field, fieldErr := range validationErrors {
+			problem.Errors[field] = fieldErr.Error()
+		}
+	case errors.As(err, &httpErr) && httpErr.Code >= http.StatusBadRequest:
+		problem = commonResponses.Problem{Status: httpErr.Code, Code: statusCode(httpErr.Code), Title: http.StatusText(httpErr.Code)}
+
+		if message, ok := httpErr.Message.(string); ok && httpErr.Code < http.StatusInternalServerError && message != problem.Title {
+			problem.Detail = message
+		}
+	default:
+		problem = commonResponses.Problem{Status: http.StatusInternalServerError, Code: "internal_error", Title: "Internal Server Error"}
+	}
+
+	problem.Type = "/problems/" + problem.Code
+
+	return problem
+}
@@ -0,0 +1,162 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errNotFound = errors.New("not found")
+	errDetailed = errors.New("detailed")
+)
+
+type detailedError struct {
+	reason string
+}
+
+func (e detailedError) Error() string        { return e.reason }
+func (e detailedError) Is(target error) bool { return target == errDetailed }
+
+func TestErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	registry.Register(errNotFound, Definition{Status: http.StatusNotFound, Code: "thing_not_found", Title: "Thing not found"})
+	registry.Register(errDetailed, Definition{
+		Status: http.StatusConflict,
+		Code:   "detailed",
+		Title:  "Detailed",
+		Extensions: func(err error) map[string]any {
+			var detailed detailedError
+			if !errors.As(err, &detailed) {
+				return nil
+			}
+
+			return map[string]any{"reason": detailed.reason}
+		},
+	})
+
+	tests := map[string]struct {
+		err        error
+		wantStatus int
+		wantBody   map[string]any
+	}{
+		"registered error": {
+			err:        fmt.Errorf("get thing: %w", errNotFound),
+			wantStatus: http.StatusNotFound,
+			wantBody:   map[string]any{"code": "thing_not_found", "title": "Thing not found"},
+		},
+		"extensions": {
+			err:        detailedError{reason: "taken"},
+			wantStatus: http.StatusConflict,
+			wantBody:   map[string]any{"code": "detailed", "title": "Detailed", "reason": "taken"},
+		},
+		"handler problem": {
+			err:        New(http.StatusBadRequest, "invalid_id", "Invalid ID").Wrap(errNotFound),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   map[string]any{"code": "invalid_id", "title": "Invalid ID"},
+		},
+		"validation": {
+			err:        validation.Errors{"email": errors.New("must be a valid email address")},
+			wantStatus: http.StatusBadRequest,
+			wantBody: map[string]any{
+				"code":   "invalid_request",
+				"title":  "Request is not valid",
+				"errors": map[string]any{"email": "must be a valid email address"},
+			},
+		},
+		"echo error": {
+			err:        echo.NewHTTPError(http.StatusUnsupportedMediaType, "Unsupported content type"),
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantBody:   map[string]any{"code": "unsupported_media_type", "title": "Unsupported Media Type", "detail": "Unsupported content type"},
+		},
+		"unknown error": {
+			err:        errors.New("connection refused to 10.0.0.1"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   map[string]any{"code": "internal_error", "title": "Internal Server Error"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequest(http.MethodGet, "/things/1", nil)
+			recorder := httptest.NewRecorder()
+
+			NewErrorHandler(registry)(test.err, echo.New().NewContext(request, recorder))
+
+			assert.Equal(t, test.wantStatus, recorder.Code)
+			assert.Equal(t, ContentType, recorder.Header().Get(echo.HeaderContentType))
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+			want := map[string]any{
+				"type":     "/problems/" + test.wantBody["code"].(string),
+				"status":   float64(test.wantStatus),
+				"instance": "/things/1",
+			}
+			for key, value := range test.wantBody {
+				want[key] = value
+			}
+
+			assert.Equal(t, want, body)
+		})
+	}
+}
+
+func TestErrorHandlerTraceID(t *testing.T) {
+	t.Parallel()
+
+	tracer := slogx.NewTraceStarter(func() (uuid.UUID, error) {
+		return uuid.MustParse("11111111-1111-1111-1111-111111111111"), nil
+	})
+
+	ctx, err := tracer.Start(t.Context())
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(request, recorder)
+
+	handler := NewErrorHandler(NewRegistry())
+	handler(errors.New("boom"), c)
+	// A second call, as made by outer middlewares, does not write again
+	handler(errors.New("boom"), c)
+
+	var body struct {
+		TraceID string `json:"traceId"`
+	}
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&body))
+
+	assert.Equal(t, "11111111111111111111111111111111", body.TraceID)
+	assert.False(t, json.NewDecoder(recorder.Body).More())
+}
+
+func TestRegistryOrder(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	registry.Register(errDetailed, Definition{Code: "first"})
+	registry.Register(errNotFound, Definition{Code: "second"})
+
+	definition, ok := registry.Lookup(errors.Join(errNotFound, errDetailed))
+	require.True(t, ok)
+	assert.Equal(t, "first", definition.Code)
+
+	_, ok = registry.Lookup(errors.New("other"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,58 @@
+// Package problem answers failed requests with problem details (RFC 7807). Handlers return errors,
+// and the error handler maps them to a status and a stable code with a [Registry].
+package problem
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ContentType is the media type of problem details.
+const ContentType = "application/problem+json"
+
+// Error is a problem that is specific to a handler, such as a malformed path parameter. Errors
+// that mean the same on every route belong in the [Registry] instead.
+type Error struct {
+	Status int
+	Code   string
+	Title  string
+
+	// Err is the cause, which is logged but not shown to the client.
+	Err error
+}
+
+// New returns a problem with the given status, code and title.
+func New(status int, code, title string) *Error {
+	return &Error{Status: status, Code: code, Title: title}
+}
+
+// Wrap returns a copy of the problem caused by err.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+
+	return &wrapped
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Code
+	}
+
+	return fmt.Sprintf("%s: %v", e.Code, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// statusCode returns the code of a status without a more specific one, e.g. "not_found".
+func statusCode(status int) string {
+	text := strings.ToLower(http.StatusText(status))
+	if text == "" {
+		return "error"
+	}
+
+	return strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text)
+}
@@ -0,0 +1,44 @@
+package problem
+
+import "errors"
+
+// Definition is how requests that fail with an error are answered.
+type Definition struct {
+	Status int
+	Code   string
+	Title  string
+
+	// Extensions returns additional members of the problem for the matched error. It is optional.
+	Extensions func(err error) map[string]any
+}
+
+// Registry maps domain errors, such as a missing user, to their problem. An error matches the first
+// registered target it wraps, so register the more specific targets first.
+type Registry struct {
+	entries []entry
+}
+
+type entry struct {
+	target     error
+	definition Definition
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register answers errors that match target with errors.Is as described by definition.
+func (r *Registry) Register(target error, definition Definition) {
+	r.entries = append(r.entries, entry{target: target, definition: definition})
+}
+
+// Lookup returns the definition of the first registered target that err matches.
+func (r *Registry) Lookup(err error) (Definition, bool) {
+	for _, entry := range r.entries {
+		if errors.Is(err, entry.target) {
+			return entry.definition, true
+		}
+	}
+
+	return Definition{}, false
+}
@@ -2,6 +2,11 @@
 package routes
 
 import (
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	auditHandlers "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/handlers/audit"
+	challengeHandlers "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/handlers/challenge"
+	healthHandlers "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/handlers/health"
+	loggingHandlers "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/handlers/logging"
 	handlers "github.com/game-platform-ai/golang-echo-boilerplate/internal/server/handlers/user-auth"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/middleware"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
@@ -16,35 +21,107 @@ type Handlers struct {
 	OAuthHandler    *handlers.OAuthHandler
 	RegisterHandler *handlers.RegisterHandler
 
-	EchoJWTMiddleware echo.MiddlewareFunc
+	EmailLoginHandler *handlers.EmailLoginHandler
+	PhoneHandler      *handlers.PhoneHandler
+
+	LoginHistoryHandler  *handlers.LoginHistoryHandler
+	UserAdminHandler     *handlers.UserAdminHandler
+	ImpersonationHandler *handlers.ImpersonationHandler
+	PasswordHandler      *handlers.PasswordHandler
+	AuditHandler         *auditHandlers.AuditHandler
+	ChallengeHandler     *challengeHandlers.ChallengeHandler
+	HealthHandler        *healthHandlers.HealthHandler
+	LoggingHandler       *loggingHandlers.LoggingHandler
+
+	// MetricsMiddleware measures every request, so it runs first
+	MetricsMiddleware echo.MiddlewareFunc
+	// TracingMiddleware starts the span whose trace ID the request logger logs
+	TracingMiddleware echo.MiddlewareFunc
+	// DebugEscalationMiddleware logs requests with a valid debug token at DEBUG level
+	DebugEscalationMiddleware echo.MiddlewareFunc
+	EchoJWTMiddleware         echo.MiddlewareFunc
+
+	// ChallengeMiddleware protects routes that bots abuse: sign-ups and one-time code requests
+	ChallengeMiddleware echo.MiddlewareFunc
+
+	// ReadYourWritesMiddleware pins reads to the primary database after a write
+	ReadYourWritesMiddleware echo.MiddlewareFunc
+	// RequestDebuggerMiddleware logs redacted, size-capped request and response bodies of sampled
+	// requests at DEBUG level
+	RequestDebuggerMiddleware echo.MiddlewareFunc
 }
 
 func ConfigureRoutes(tracer *slogx.TraceStarter, engine *echo.Echo, handlers Handlers) error {
+	engine.Use(handlers.MetricsMiddleware)
+	engine.Use(handlers.TracingMiddleware)
+	engine.Use(handlers.DebugEscalationMiddleware)
+
 	// CORS middleware
 	engine.Use(echomiddleware.CORSWithConfig(echomiddleware.CORSConfig{
 		AllowOrigins:     []string{"http://localhost:3000"},
 		AllowMethods:     []string{"*"},
 		AllowHeaders:     []string{"*"},
+		ExposeHeaders:    []string{slogx.RequestIDHeader},
 		AllowCredentials: true,
 	}))
 
 	engine.Use(middleware.NewRequestLogger(tracer))
+	engine.Use(middleware.NewClientInfo())
+	engine.Use(handlers.ReadYourWritesMiddleware)
+
+	// Probes
+	engine.GET("/healthz", handlers.HealthHandler.Live)
+	engine.GET("/readyz", handlers.HealthHandler.Ready)
 
 	// Swagger documentation
 	engine.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	// API group with prefix api/external/v1
 	apiGroup := engine.Group("/api/external/v1")
+	apiGroup.Use(handlers.RequestDebuggerMiddleware)
 
 	// Public endpoints - no authentication required
+	apiGroup.GET("/challenge", handlers.ChallengeHandler.Issue)
 	apiGroup.POST("/login", handlers.AuthHandler.Login)
-	apiGroup.POST("/register", handlers.RegisterHandler.Register)
+	apiGroup.POST("/register", handlers.RegisterHandler.Register, handlers.ChallengeMiddleware)
 	apiGroup.POST("/google-oauth", handlers.OAuthHandler.GoogleOAuth)
 	apiGroup.POST("/refresh", handlers.AuthHandler.RefreshToken)
+	apiGroup.POST("/login/email-link", handlers.EmailLoginHandler.RequestLink, handlers.ChallengeMiddleware)
+	apiGroup.POST("/login/email-link/verify", handlers.EmailLoginHandler.VerifyLink)
+	apiGroup.POST("/login/phone", handlers.PhoneHandler.RequestLogin, handlers.ChallengeMiddleware)
+	apiGroup.POST("/login/phone/verify", handlers.PhoneHandler.VerifyLogin)
 
 	protectedGroup := apiGroup.Group("")
-	protectedGroup.Use(middleware.NewRequestDebugger())
 	protectedGroup.Use(handlers.EchoJWTMiddleware)
+	protectedGroup.Use(middleware.NewRequestAttributes())
+	protectedGroup.Use(middleware.NewAuditActor())
+	protectedGroup.Use(middleware.NewImpersonationLogger())
+
+	// Sensitive actions are not available with an impersonation token
+	denyImpersonation := middleware.NewImpersonationGuard()
+
+	protectedGroup.GET("/me/logins", handlers.LoginHistoryHandler.ListMine)
+	protectedGroup.POST("/me/password", handlers.PasswordHandler.ChangePassword, denyImpersonation)
+	protectedGroup.POST("/me/phone", handlers.PhoneHandler.RequestVerification, denyImpersonation, handlers.ChallengeMiddleware)
+	protectedGroup.POST("/me/phone/verify", handlers.PhoneHandler.Verify, denyImpersonation)
+
+	adminGroup := protectedGroup.Group("/admin")
+	adminGroup.Use(denyImpersonation)
+	adminGroup.Use(middleware.NewRoleGuard(models.RoleAdmin))
+
+	adminGroup.GET("/users/:id/logins", handlers.LoginHistoryHandler.ListByUser)
+	adminGroup.POST("/users/:id/ban", handlers.UserAdminHandler.Ban)
+	adminGroup.POST("/users/:id/unban", handlers.UserAdminHandler.Unban)
+	adminGroup.PUT("/users/:id/role", handlers.UserAdminHandler.ChangeRole)
+	adminGroup.POST("/users/:id/impersonate", handlers.ImpersonationHandler.Impersonate)
+
+	adminGroup.GET("/audit-logs", handlers.AuditHandler.List)
+	adminGroup.GET("/audit-logs/export", handlers.AuditHandler.Export)
+	adminGroup.GET("/audit-logs/verify", handlers.AuditHandler.Verify)
+
+	adminGroup.GET("/log/levels", handlers.LoggingHandler.Levels)
+	adminGroup.PUT("/log/levels", handlers.LoggingHandler.SetLevel)
+	adminGroup.POST("/log/debug-tokens", handlers.LoggingHandler.IssueDebugToken)
 
 	return nil
 }
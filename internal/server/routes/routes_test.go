@@ -0,0 +1,79 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/problem"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/server/routes"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func passThrough(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+// TestImpersonationGuardedRoutes checks that sensitive routes refuse impersonation tokens before
+// reaching their handlers, which are nil here.
+func TestImpersonationGuardedRoutes(t *testing.T) {
+	t.Parallel()
+
+	// Stands in for the JWT middleware with an admin's impersonation token for a user
+	impersonationToken := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user", &jwt.Token{Claims: &token.JwtCustomClaims{
+				ID:   uuid.New(),
+				Role: models.RoleAdmin,
+				Act:  &token.ActorClaim{Subject: uuid.New()},
+			}})
+
+			return next(c)
+		}
+	}
+
+	engine := echo.New()
+	engine.HTTPErrorHandler = problem.NewErrorHandler(problem.NewRegistry())
+
+	err := routes.ConfigureRoutes(slogx.NewTraceStarter(uuid.NewRandom), engine, routes.Handlers{
+		MetricsMiddleware:         passThrough,
+		TracingMiddleware:         passThrough,
+		DebugEscalationMiddleware: passThrough,
+		EchoJWTMiddleware:         impersonationToken,
+		ChallengeMiddleware:       passThrough,
+		ReadYourWritesMiddleware:  passThrough,
+		RequestDebuggerMiddleware: passThrough,
+	})
+	require.NoError(t, err)
+
+	for _, route := range []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/api/external/v1/me/password"},
+		{http.MethodPost, "/api/external/v1/me/phone"},
+		{http.MethodPost, "/api/external/v1/me/phone/verify"},
+		{http.MethodPost, "/api/external/v1/admin/users/" + uuid.NewString() + "/ban"},
+		{http.MethodPost, "/api/external/v1/admin/users/" + uuid.NewString() + "/impersonate"},
+		{http.MethodGet, "/api/external/v1/admin/audit-logs"},
+		{http.MethodPut, "/api/external/v1/admin/log/levels"},
+	} {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequestWithContext(t.Context(), route.method, route.path, http.NoBody)
+			response := httptest.NewRecorder()
+			engine.ServeHTTP(response, request)
+
+			assert.Equal(t, http.StatusForbidden, response.Code)
+			assert.Contains(t, response.Body.String(), "impersonation_not_allowed")
+		})
+	}
+}
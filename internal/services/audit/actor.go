@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ActorTypeUser      = "user"
+	ActorTypeSystem    = "system"
+	ActorTypeAnonymous = "anonymous"
+	ActorTypeCLI       = "cli"
+)
+
+// Actor is whoever performed an audited action.
+type Actor struct {
+	Type string
+	ID   *uuid.UUID
+}
+
+var (
+	SystemActor    = Actor{Type: ActorTypeSystem}
+	AnonymousActor = Actor{Type: ActorTypeAnonymous}
+
+	// CLIActor is an operator running an administrative command on a server.
+	CLIActor = Actor{Type: ActorTypeCLI}
+)
+
+func UserActor(id uuid.UUID) Actor {
+	return Actor{Type: ActorTypeUser, ID: &id}
+}
+
+type actorKeyType int8
+
+var actorKey actorKeyType = 1
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorFromContext returns the actor stored in the context, or [AnonymousActor] if there is none.
+func ActorFromContext(ctx context.Context) Actor {
+	actor, ok := ctx.Value(actorKey).(Actor)
+	if !ok {
+		return AnonymousActor
+	}
+
+	return actor
+}
@@ -0,0 +1,275 @@
+// Package audit records who changed what in an append-only, hash-chained log.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/audit"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/clientinfo"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type Action string
+
+const (
+	ActionUserRegister       Action = "user.register"
+	ActionUserBan            Action = "user.ban"
+	ActionUserUnban          Action = "user.unban"
+	ActionUserRole           Action = "user.role_change"
+	ActionUserPasswordChange Action = "user.password_change"
+	ActionUserPasswordReset  Action = "user.password_reset"
+	ActionUserPhoneVerify    Action = "user.phone_verify"
+	ActionUserEmailVerify    Action = "user.email_verify"
+	ActionUserImpersonate    Action = "user.impersonate"
+	ActionKeyRotate          Action = "key.rotate"
+	ActionLogLevelChange     Action = "log.level_change"
+	ActionLogDebugToken      Action = "log.debug_token"
+)
+
+const (
+	TargetTypeUser       = "user"
+	TargetTypeSigningKey = "signing_key"
+	TargetTypeLogger     = "logger"
+)
+
+// Target is the object an audited action was applied to.
+type Target struct {
+	Type string
+	ID   string
+}
+
+// Change describes how a single field changed.
+type Change struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// Diff maps changed field names to their changes.
+type Diff map[string]Change
+
+const batchSize = 500
+
+var errStopIteration = errors.New("stop iteration")
+
+type entryRepository interface {
+	Append(ctx context.Context, entry *models.Entry, seal func(entry *models.Entry, prevHash string)) error
+	List(ctx context.Context, filter models.EntryFilter, limit, offset int) ([]models.Entry, int64, error)
+	Each(ctx context.Context, filter models.EntryFilter, batchSize int, fn func(entry *models.Entry) error) error
+	Latest(ctx context.Context, action, targetType, targetID string) (models.Entry, error)
+	Locked(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	now             func() time.Time
+	entryRepository entryRepository
+}
+
+func NewService(now func() time.Time, entryRepository entryRepository) *Service {
+	return &Service{now: now, entryRepository: entryRepository}
+}
+
+// Record appends an entry to the audit log. diff may be nil or any JSON-serializable value.
+func (s *Service) Record(ctx context.Context, actor Actor, action Action, target Target, diff any) error {
+	rawDiff, err := json.Marshal(diff)
+	if err != nil {
+		return fmt.Errorf("marshal audit diff: %w", err)
+	}
+
+	traceID, _ := slogx.TraceID(ctx)
+
+	entry := &models.Entry{
+		// Postgres keeps microseconds, so the hashed time must not be more precise than the stored one.
+		CreatedAt:  s.now().UTC().Truncate(time.Microsecond),
+		ActorType:  actor.Type,
+		ActorID:    actor.ID,
+		Action:     string(action),
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		Diff:       string(rawDiff),
+		TraceID:    traceID,
+		IP:         clientinfo.FromContext(ctx).IP,
+	}
+
+	err = s.entryRepository.Append(ctx, entry, func(entry *models.Entry, prevHash string) {
+		entry.PrevHash = prevHash
+		entry.Hash = hashEntry(entry)
+	})
+	if err != nil {
+		return fmt.Errorf("append audit entry in repository: %w", err)
+	}
+
+	return nil
+}
+
+// RecordKeyRotation records a key rotation if the fingerprint of the named key differs from the last recorded one.
+// The check and the record hold the append lock, so instances starting together record a rotation once.
+func (s *Service) RecordKeyRotation(ctx context.Context, keyName, fingerprint string) error {
+	return s.entryRepository.Locked(ctx, func(ctx context.Context) error {
+		previous := ""
+
+		latest, err := s.entryRepository.Latest(ctx, string(ActionKeyRotate), TargetTypeSigningKey, keyName)
+		switch {
+		case errors.Is(err, models.ErrEntryNotFound):
+		case err != nil:
+			return fmt.Errorf("get latest key rotation from repository: %w", err)
+		default:
+			var diff Diff
+			if err := json.Unmarshal([]byte(latest.Diff), &diff); err != nil {
+				return fmt.Errorf("unmarshal key rotation diff: %w", err)
+			}
+
+			previous, _ = diff["fingerprint"].To.(string)
+		}
+
+		if previous == fingerprint {
+			return nil
+		}
+
+		var from any
+		if previous != "" {
+			from = previous
+		}
+
+		diff := Diff{"fingerprint": {From: from, To: fingerprint}}
+		if err := s.Record(ctx, SystemActor, ActionKeyRotate, Target{Type: TargetTypeSigningKey, ID: keyName}, diff); err != nil {
+			return fmt.Errorf("record key rotation: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *Service) List(ctx context.Context, filter models.EntryFilter, page, pageSize int) ([]models.Entry, int64, error) {
+	entries, total, err := s.entryRepository.List(ctx, filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list audit entries from repository: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// ExportCSV writes every entry matching the filter to w as CSV, in chain order.
+func (s *Service) ExportCSV(ctx context.Context, filter models.EntryFilter, w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{
+		"seq", "created_at", "actor_type", "actor_id", "action", "target_type", "target_id",
+		"diff", "trace_id", "ip", "prev_hash", "hash",
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+
+	err := s.entryRepository.Each(ctx, filter, batchSize, func(entry *models.Entry) error {
+		actorID := ""
+		if entry.ActorID != nil {
+			actorID = entry.ActorID.String()
+		}
+
+		record := []string{
+			strconv.FormatInt(entry.Seq, 10),
+			entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+			entry.ActorType,
+			actorID,
+			entry.Action,
+			entry.TargetType,
+			entry.TargetID,
+			entry.Diff,
+			entry.TraceID,
+			entry.IP,
+			entry.PrevHash,
+			entry.Hash,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("write csv record: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("export audit entries: %w", err)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("flush csv: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyResult reports the outcome of a chain verification.
+type VerifyResult struct {
+	Checked int64
+	// BrokenAt is the sequence number of the first entry that does not match the chain, if any.
+	BrokenAt *int64
+}
+
+// Verify walks the whole chain in order and recomputes every hash.
+func (s *Service) Verify(ctx context.Context) (VerifyResult, error) {
+	var (
+		result   VerifyResult
+		prevHash string
+	)
+
+	err := s.entryRepository.Each(ctx, models.EntryFilter{}, batchSize, func(entry *models.Entry) error {
+		if entry.PrevHash != prevHash || hashEntry(entry) != entry.Hash {
+			result.BrokenAt = &entry.Seq
+			return errStopIteration
+		}
+
+		prevHash = entry.Hash
+		result.Checked++
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopIteration) {
+		return VerifyResult{}, fmt.Errorf("verify audit chain: %w", err)
+	}
+
+	return result, nil
+}
+
+func hashEntry(entry *models.Entry) string {
+	actorID := ""
+	if entry.ActorID != nil {
+		actorID = entry.ActorID.String()
+	}
+
+	fields := []string{
+		entry.PrevHash,
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		entry.ActorType,
+		actorID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		entry.Diff,
+		entry.TraceID,
+		entry.IP,
+	}
+
+	// Every field is length-prefixed so that moving bytes between adjacent fields changes the hash.
+	var builder strings.Builder
+	for _, field := range fields {
+		builder.WriteString(strconv.Itoa(len(field)))
+		builder.WriteByte(':')
+		builder.WriteString(field)
+	}
+
+	sum := sha256.Sum256([]byte(builder.String()))
+
+	return hex.EncodeToString(sum[:])
+}
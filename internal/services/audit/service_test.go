@@ -0,0 +1,110 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/audit"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryRepository struct {
+	entries []models.Entry
+}
+
+func (r *memoryRepository) Append(_ context.Context, entry *models.Entry, seal func(entry *models.Entry, prevHash string)) error {
+	prevHash := ""
+	if len(r.entries) > 0 {
+		prevHash = r.entries[len(r.entries)-1].Hash
+	}
+
+	seal(entry, prevHash)
+	entry.Seq = int64(len(r.entries) + 1)
+	r.entries = append(r.entries, *entry)
+
+	return nil
+}
+
+func (r *memoryRepository) List(context.Context, models.EntryFilter, int, int) ([]models.Entry, int64, error) {
+	return r.entries, int64(len(r.entries)), nil
+}
+
+func (r *memoryRepository) Each(_ context.Context, _ models.EntryFilter, _ int, fn func(entry *models.Entry) error) error {
+	for i := range r.entries {
+		if err := fn(&r.entries[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryRepository) Latest(_ context.Context, action, targetType, targetID string) (models.Entry, error) {
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		entry := r.entries[i]
+		if entry.Action == action && entry.TargetType == targetType && entry.TargetID == targetID {
+			return entry, nil
+		}
+	}
+
+	return models.Entry{}, models.ErrEntryNotFound
+}
+
+func (r *memoryRepository) Locked(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func newTestService(repository *memoryRepository) *audit.Service {
+	now := time.Date(2025, 5, 9, 10, 0, 0, 0, time.UTC)
+
+	return audit.NewService(func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}, repository)
+}
+
+func TestVerify(t *testing.T) {
+	repository := &memoryRepository{}
+	service := newTestService(repository)
+
+	ctx := t.Context()
+	target := audit.Target{Type: audit.TargetTypeUser, ID: uuid.NewString()}
+
+	require.NoError(t, service.Record(ctx, audit.UserActor(uuid.New()), audit.ActionUserBan, target, audit.Diff{
+		"status": {From: "ACTIVE", To: "BANNED"},
+	}))
+	require.NoError(t, service.Record(ctx, audit.SystemActor, audit.ActionUserUnban, target, nil))
+	require.NoError(t, service.Record(ctx, audit.AnonymousActor, audit.ActionUserRegister, target, nil))
+
+	result, err := service.Verify(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, audit.VerifyResult{Checked: 3}, result)
+
+	repository.entries[1].Action = string(audit.ActionUserBan)
+
+	result, err = service.Verify(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, result.BrokenAt)
+	assert.Equal(t, int64(2), *result.BrokenAt)
+	assert.Equal(t, int64(1), result.Checked)
+}
+
+func TestRecordKeyRotation(t *testing.T) {
+	repository := &memoryRepository{}
+	service := newTestService(repository)
+
+	ctx := t.Context()
+
+	require.NoError(t, service.RecordKeyRotation(ctx, "access", "aaaa"))
+	require.NoError(t, service.RecordKeyRotation(ctx, "access", "aaaa"))
+	require.NoError(t, service.RecordKeyRotation(ctx, "refresh", "cccc"))
+	require.NoError(t, service.RecordKeyRotation(ctx, "access", "bbbb"))
+
+	require.Len(t, repository.entries, 3)
+	assert.JSONEq(t, `{"fingerprint":{"from":null,"to":"aaaa"}}`, repository.entries[0].Diff)
+	assert.JSONEq(t, `{"fingerprint":{"from":"aaaa","to":"bbbb"}}`, repository.entries[2].Diff)
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// DatabaseCheck pings the database.
+func DatabaseCheck(db pinger) Check {
+	return Check{Name: "database", Run: db.PingContext}
+}
+
+type schemaVersioner interface {
+	Version(ctx context.Context) (int64, error)
+	LatestVersion() int64
+}
+
+// MigrationCheck fails while the database schema is older than the latest migration of this build.
+// A newer schema is fine: it happens while a new version rolls out.
+func MigrationCheck(migrator schemaVersioner) Check {
+	return Check{Name: "migrations", Run: func(ctx context.Context) error {
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+
+		if latest := migrator.LatestVersion(); version < latest {
+			return fmt.Errorf("schema version %d is older than %d", version, latest)
+		}
+
+		return nil
+	}}
+}
+
+// KeySetCheck fetches the signing keys of an OIDC provider, which ID token verification needs. A
+// successful fetch is trusted for cacheFor, so that probes do not call the provider every time. The
+// check is optional: a provider outage breaks OAuth logins only, and must not make every instance
+// unready.
+func KeySetCheck(now func() time.Time, client *http.Client, jwksURL string, cacheFor time.Duration) Check {
+	var (
+		mu        sync.Mutex
+		checkedAt time.Time
+	)
+
+	return Check{Name: "oidc", Optional: true, Run: func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !checkedAt.IsZero() && now().Sub(checkedAt) < cacheFor {
+			return nil
+		}
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURL, nil)
+		if err != nil {
+			return fmt.Errorf("new request: %w", err)
+		}
+
+		response, err := client.Do(request)
+		if err != nil {
+			return fmt.Errorf("fetch key set: %w", err)
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("fetch key set: unexpected status %d", response.StatusCode)
+		}
+
+		var keySet struct {
+			Keys []json.RawMessage `json:"keys"`
+		}
+		if err := json.NewDecoder(response.Body).Decode(&keySet); err != nil {
+			return fmt.Errorf("decode key set: %w", err)
+		}
+
+		if len(keySet.Keys) == 0 {
+			return fmt.Errorf("key set is empty")
+		}
+
+		checkedAt = now()
+
+		return nil
+	}}
+}
@@ -0,0 +1,114 @@
+// Package health reports whether the service and its dependencies can take traffic.
+package health
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrDraining is reported while the service shuts down.
+var ErrDraining = errors.New("service is shutting down")
+
+// Check verifies a single dependency.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+	// Optional checks are reported, but their failure does not make the service unready. Use it for
+	// dependencies that only some requests need, whose outage must not take every instance out.
+	Optional bool
+}
+
+// Result is the outcome of a check.
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+	Optional bool
+}
+
+// Report is the outcome of a readiness check.
+type Report struct {
+	Ready    bool
+	Draining bool
+	Results  []Result
+}
+
+type Service struct {
+	timeout  time.Duration
+	checks   []Check
+	draining atomic.Bool
+}
+
+// NewService creates a service running checks with a timeout each.
+func NewService(timeout time.Duration, checks ...Check) *Service {
+	return &Service{timeout: timeout, checks: checks}
+}
+
+// Drain makes the service report not ready from now on, so that load balancers stop sending traffic
+// before the server shuts down.
+func (s *Service) Drain() {
+	s.draining.Store(true)
+}
+
+// Ready runs every check concurrently and logs the failures. While draining, no checks are run.
+func (s *Service) Ready(ctx context.Context) Report {
+	if s.draining.Load() {
+		return Report{Draining: true}
+	}
+
+	results := make([]Result, len(s.checks))
+
+	var wg sync.WaitGroup
+
+	for i, check := range s.checks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[i] = s.run(ctx, check)
+		}()
+	}
+
+	wg.Wait()
+
+	ready := true
+	for _, result := range results {
+		if result.Err == nil {
+			continue
+		}
+
+		slog.WarnContext(ctx, "Readiness check failed",
+			"check", result.Name, "optional", result.Optional, "err", result.Err.Error())
+
+		ready = ready && result.Optional
+	}
+
+	return Report{Ready: ready, Results: results}
+}
+
+func (s *Service) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Run(ctx)
+	}()
+
+	// A check that ignores its context must not hold up the report.
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	return Result{Name: check.Name, Err: err, Duration: time.Since(start), Optional: check.Optional}
+}
@@ -0,0 +1,82 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceReady(t *testing.T) {
+	t.Parallel()
+
+	errDown := errors.New("down")
+	blocked := make(chan struct{})
+	t.Cleanup(func() { close(blocked) })
+
+	service := health.NewService(50*time.Millisecond,
+		health.Check{Name: "ok", Run: func(context.Context) error { return nil }},
+		health.Check{Name: "failing", Run: func(context.Context) error { return errDown }},
+		health.Check{Name: "stuck", Run: func(context.Context) error { <-blocked; return nil }},
+	)
+
+	report := service.Ready(context.Background())
+	assert.False(t, report.Ready)
+	assert.False(t, report.Draining)
+	require.Len(t, report.Results, 3)
+
+	assert.Equal(t, "ok", report.Results[0].Name)
+	assert.NoError(t, report.Results[0].Err)
+	assert.ErrorIs(t, report.Results[1].Err, errDown)
+	assert.ErrorIs(t, report.Results[2].Err, context.DeadlineExceeded)
+}
+
+func TestServiceReadyOptional(t *testing.T) {
+	t.Parallel()
+
+	service := health.NewService(time.Second,
+		health.Check{Name: "ok", Run: func(context.Context) error { return nil }},
+		health.Check{Name: "oidc", Optional: true, Run: func(context.Context) error { return errors.New("down") }},
+	)
+
+	report := service.Ready(context.Background())
+	assert.True(t, report.Ready, "optional checks do not fail readiness")
+	require.Len(t, report.Results, 2)
+	assert.Error(t, report.Results[1].Err)
+	assert.True(t, report.Results[1].Optional)
+}
+
+func TestServiceDrain(t *testing.T) {
+	t.Parallel()
+
+	service := health.NewService(time.Second, health.Check{Name: "ok", Run: func(context.Context) error { return nil }})
+	assert.True(t, service.Ready(context.Background()).Ready)
+
+	service.Drain()
+
+	report := service.Ready(context.Background())
+	assert.False(t, report.Ready)
+	assert.True(t, report.Draining)
+	assert.Empty(t, report.Results)
+}
+
+type fakeMigrator struct {
+	version, latest int64
+}
+
+func (m fakeMigrator) Version(context.Context) (int64, error) { return m.version, nil }
+func (m fakeMigrator) LatestVersion() int64                   { return m.latest }
+
+func TestMigrationCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	assert.NoError(t, health.MigrationCheck(fakeMigrator{version: 3, latest: 3}).Run(ctx))
+	assert.NoError(t, health.MigrationCheck(fakeMigrator{version: 4, latest: 3}).Run(ctx), "newer schema during a rollout")
+	assert.ErrorContains(t, health.MigrationCheck(fakeMigrator{version: 2, latest: 3}).Run(ctx), "older than 3")
+}
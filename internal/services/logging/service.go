@@ -0,0 +1,135 @@
+// Package logging lets admins change log levels at runtime and issue tokens that log a single
+// request at DEBUG level.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+var (
+	ErrInvalidLevel           = errors.New("invalid log level")
+	ErrInvalidTTL             = errors.New("invalid debug token ttl")
+	ErrDebugTokensUnavailable = errors.New("debug tokens are not configured")
+)
+
+// defaultTarget is the audit target ID of the level of packages without override.
+const defaultTarget = "default"
+
+type levelStore interface {
+	Level() slog.Level
+	SetLevel(level slog.Level)
+	Overrides() map[string]slog.Level
+	SetOverride(packagePath string, level slog.Level)
+	RemoveOverride(packagePath string)
+}
+
+type debugTokenIssuer interface {
+	Issue(ttl time.Duration) (string, time.Time)
+}
+
+type auditor interface {
+	Record(ctx context.Context, actor audit.Actor, action audit.Action, target audit.Target, diff any) error
+}
+
+// Levels is the current level and the overrides by package import path.
+type Levels struct {
+	Level     slog.Level
+	Overrides map[string]slog.Level
+}
+
+type Service struct {
+	levels      levelStore
+	debugTokens debugTokenIssuer
+	maxTTL      time.Duration
+	auditor     auditor
+}
+
+// NewService returns the service. debugTokens may be nil when no debug secret is configured.
+func NewService(levels levelStore, debugTokens debugTokenIssuer, maxTTL time.Duration, auditor auditor) *Service {
+	return &Service{levels: levels, debugTokens: debugTokens, maxTTL: maxTTL, auditor: auditor}
+}
+
+func (s *Service) Levels() Levels {
+	return Levels{Level: s.levels.Level(), Overrides: s.levels.Overrides()}
+}
+
+// SetLevel sets the level of a package, or of packages without override when packagePath is
+// empty. An empty level removes the override of the package.
+func (s *Service) SetLevel(ctx context.Context, packagePath, levelName string) error {
+	var (
+		level slog.Level
+		err   error
+	)
+
+	if levelName != "" {
+		if level, err = slogx.ParseLevel(levelName); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidLevel, err)
+		}
+	} else if packagePath == "" {
+		return fmt.Errorf("empty level without package: %w", ErrInvalidLevel)
+	}
+
+	target := audit.Target{Type: audit.TargetTypeLogger, ID: packagePath}
+
+	var from any
+	switch {
+	case packagePath == "":
+		target.ID = defaultTarget
+		from = s.levels.Level().String()
+		s.levels.SetLevel(level)
+	default:
+		if previous, ok := s.levels.Overrides()[packagePath]; ok {
+			from = previous.String()
+		}
+
+		if levelName == "" {
+			s.levels.RemoveOverride(packagePath)
+		} else {
+			s.levels.SetOverride(packagePath, level)
+		}
+	}
+
+	var to any
+	if levelName != "" {
+		to = level.String()
+	}
+
+	diff := audit.Diff{"level": {From: from, To: to}}
+	if err := s.auditor.Record(ctx, audit.ActorFromContext(ctx), audit.ActionLogLevelChange, target, diff); err != nil {
+		return fmt.Errorf("record log level change: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Log level changed", "logger", target.ID, "from", from, "to", to)
+
+	return nil
+}
+
+// IssueDebugToken returns a token for the X-Debug-Token header, valid for ttl, and its expiry.
+func (s *Service) IssueDebugToken(ctx context.Context, ttl time.Duration) (string, time.Time, error) {
+	if s.debugTokens == nil {
+		return "", time.Time{}, ErrDebugTokensUnavailable
+	}
+
+	if ttl <= 0 || ttl > s.maxTTL {
+		return "", time.Time{}, fmt.Errorf("%w: must be in (0, %s], got %s", ErrInvalidTTL, s.maxTTL, ttl)
+	}
+
+	token, expiresAt := s.debugTokens.Issue(ttl)
+
+	diff := audit.Diff{"expiresAt": {To: expiresAt}}
+	target := audit.Target{Type: audit.TargetTypeLogger, ID: defaultTarget}
+	if err := s.auditor.Record(ctx, audit.ActorFromContext(ctx), audit.ActionLogDebugToken, target, diff); err != nil {
+		return "", time.Time{}, fmt.Errorf("record debug token: %w", err)
+	}
+
+	return token, expiresAt, nil
+}
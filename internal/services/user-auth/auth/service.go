@@ -5,14 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/responses"
 	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/password"
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
 	"github.com/google/uuid"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 //go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
@@ -20,6 +21,12 @@ import (
 type userService interface {
 	GetByID(ctx context.Context, id uuid.UUID) (models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error
+}
+
+type passwordHasher interface {
+	Hash(ctx context.Context, password string) (string, error)
+	Verify(ctx context.Context, encodedHash, password string) (needsRehash bool, err error)
 }
 
 type tokenService interface {
@@ -28,28 +35,66 @@ type tokenService interface {
 	CreateRefreshToken(ctx context.Context, user *models.User) (string, error)
 }
 
+type loginRecorder interface {
+	RecordSuccess(ctx context.Context, user *models.User, method models.LoginMethod)
+	RecordFailure(ctx context.Context, userID *uuid.UUID, email string, method models.LoginMethod, reason string)
+}
+
 type Service struct {
-	userService  userService
-	tokenService tokenService
+	userService    userService
+	passwordHasher passwordHasher
+	tokenService   tokenService
+	loginRecorder  loginRecorder
 }
 
-func NewService(userService userService, tokenService tokenService) *Service {
+func NewService(
+	userService userService,
+	passwordHasher passwordHasher,
+	tokenService tokenService,
+	loginRecorder loginRecorder,
+) *Service {
 	return &Service{
-		userService:  userService,
-		tokenService: tokenService,
+		userService:    userService,
+		passwordHasher: passwordHasher,
+		tokenService:   tokenService,
+		loginRecorder:  loginRecorder,
 	}
 }
 
 func (s *Service) GenerateToken(ctx context.Context, request *requests.LoginRequest) (*responses.LoginResponse, error) {
+	ctx, end := slogx.StartSpan(ctx, "auth.GenerateToken")
+	defer end()
+
 	user, err := s.userService.GetUserByEmail(ctx, request.Email)
 	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			s.loginRecorder.RecordFailure(ctx, nil, request.Email, models.LoginMethodPassword, models.LoginFailureUserNotFound)
+		}
+
 		return nil, fmt.Errorf("get user by email: %w", err)
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password)); err != nil {
-		return nil, errors.Join(fmt.Errorf("compare hash and passowrd: %w", err), models.ErrInvalidPassword)
+	needsRehash, err := s.passwordHasher.Verify(ctx, user.PasswordHash, request.Password)
+	switch {
+	case errors.Is(err, password.ErrMismatchedPassword), errors.Is(err, password.ErrUnsupportedHash):
+		s.loginRecorder.RecordFailure(ctx, &user.ID, user.Email, models.LoginMethodPassword, models.LoginFailureInvalidPassword)
+
+		return nil, errors.Join(fmt.Errorf("verify password: %w", err), models.ErrInvalidPassword)
+	case err != nil:
+		return nil, fmt.Errorf("verify password: %w", err)
+	}
+
+	if user.Status == models.StatusBanned {
+		s.loginRecorder.RecordFailure(ctx, &user.ID, user.Email, models.LoginMethodPassword, models.LoginFailureUserBanned)
+
+		return nil, models.ErrUserBanned
 	}
 
+	// Each login starts a session, which refreshes continue
+	sessionID := token.NewSessionID()
+	ctx = token.WithSession(ctx, sessionID)
+	ctx = slogx.With(ctx, "user_id", user.ID.String(), "session_id", sessionID)
+
 	accessToken, exp, err := s.tokenService.CreateAccessToken(ctx, &user)
 	if err != nil {
 		return nil, fmt.Errorf("create access token: %w", err)
@@ -60,22 +105,51 @@ func (s *Service) GenerateToken(ctx context.Context, request *requests.LoginRequ
 		return nil, fmt.Errorf("create refresh token: %w", err)
 	}
 
+	if needsRehash {
+		s.rehashPassword(ctx, &user, request.Password)
+	}
+
+	s.loginRecorder.RecordSuccess(ctx, &user, models.LoginMethodPassword)
+
 	response := responses.NewLoginResponse(accessToken, refreshToken, exp)
 
 	return response, nil
 }
 
 func (s *Service) RefreshToken(ctx context.Context, request *requests.RefreshRequest) (*responses.LoginResponse, error) {
+	ctx, end := slogx.StartSpan(ctx, "auth.RefreshToken")
+	defer end()
+
 	claims, err := s.tokenService.ParseRefreshToken(ctx, request.Token)
 	if err != nil {
+		s.loginRecorder.RecordFailure(ctx, nil, "", models.LoginMethodRefresh, models.LoginFailureInvalidToken)
+
 		return nil, errors.Join(fmt.Errorf("parse token: %w", err), models.ErrInvalidAuthToken)
 	}
 
 	user, err := s.userService.GetByID(ctx, claims.ID)
 	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			s.loginRecorder.RecordFailure(ctx, &claims.ID, "", models.LoginMethodRefresh, models.LoginFailureUserNotFound)
+		}
+
 		return nil, fmt.Errorf("get user by email: %w", err)
 	}
 
+	if user.Status == models.StatusBanned {
+		s.loginRecorder.RecordFailure(ctx, &user.ID, user.Email, models.LoginMethodRefresh, models.LoginFailureUserBanned)
+
+		return nil, models.ErrUserBanned
+	}
+
+	// Tokens issued before sessions existed start one
+	sessionID := claims.SessionID
+	if sessionID == "" {
+		sessionID = token.NewSessionID()
+	}
+	ctx = token.WithSession(ctx, sessionID)
+	ctx = slogx.With(ctx, "user_id", user.ID.String(), "session_id", sessionID)
+
 	accessToken, exp, err := s.tokenService.CreateAccessToken(ctx, &user)
 	if err != nil {
 		return nil, fmt.Errorf("create access token: %w", err)
@@ -86,7 +160,26 @@ func (s *Service) RefreshToken(ctx context.Context, request *requests.RefreshReq
 		return nil, fmt.Errorf("create refresh token: %w", err)
 	}
 
+	s.loginRecorder.RecordSuccess(ctx, &user, models.LoginMethodRefresh)
+
 	response := responses.NewLoginResponse(accessToken, refreshToken, exp)
 
 	return response, nil
 }
+
+// rehashPassword upgrades a hash produced by an outdated algorithm or parameters.
+// The login already succeeded, so failures are only logged and retried on the next login.
+func (s *Service) rehashPassword(ctx context.Context, user *models.User, plainPassword string) {
+	passwordHash, err := s.passwordHasher.Hash(ctx, plainPassword)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to rehash password", "err", err.Error())
+		return
+	}
+
+	if err := s.userService.UpdatePasswordHash(ctx, user.ID, passwordHash); err != nil {
+		slog.ErrorContext(ctx, "Failed to store rehashed password", "err", err.Error())
+		return
+	}
+
+	slog.InfoContext(ctx, "Password hash upgraded", "user_id", user.ID.String())
+}
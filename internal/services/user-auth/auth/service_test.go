@@ -0,0 +1,115 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/password"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryUserService struct {
+	user models.User
+}
+
+func (s *memoryUserService) GetByID(_ context.Context, id uuid.UUID) (models.User, error) {
+	if id != s.user.ID {
+		return models.User{}, models.ErrUserNotFound
+	}
+
+	return s.user, nil
+}
+
+func (s *memoryUserService) GetUserByEmail(_ context.Context, email string) (models.User, error) {
+	if email != s.user.Email {
+		return models.User{}, models.ErrUserNotFound
+	}
+
+	return s.user, nil
+}
+
+func (s *memoryUserService) UpdatePasswordHash(context.Context, uuid.UUID, string) error {
+	return nil
+}
+
+// plainHasher stores passwords as they are.
+type plainHasher struct{}
+
+func (plainHasher) Hash(_ context.Context, plain string) (string, error) {
+	return plain, nil
+}
+
+func (plainHasher) Verify(_ context.Context, encodedHash, plain string) (bool, error) {
+	if encodedHash != plain {
+		return false, password.ErrMismatchedPassword
+	}
+
+	return false, nil
+}
+
+type noopIssueObserver struct{}
+
+func (noopIssueObserver) ObserveTokenIssued(string) {}
+
+// sessionRecorder keeps the log attributes of the context of each successful login.
+type sessionRecorder struct {
+	sessionIDs []string
+}
+
+func (r *sessionRecorder) RecordSuccess(ctx context.Context, _ *models.User, _ models.LoginMethod) {
+	for _, attr := range slogx.Attrs(ctx) {
+		if attr.Key == "session_id" {
+			r.sessionIDs = append(r.sessionIDs, attr.Value.String())
+		}
+	}
+}
+
+func (r *sessionRecorder) RecordFailure(context.Context, *uuid.UUID, string, models.LoginMethod, string) {
+}
+
+func TestRefreshTokenKeepsSession(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tokenService := token.NewService(func() time.Time { return now }, time.Minute, time.Hour,
+		[]byte("access-secret"), []byte("refresh-secret"), noopIssueObserver{})
+
+	user := models.User{ID: uuid.New(), Email: "player@example.com", PasswordHash: "hunter2", Role: models.RoleUser}
+	recorder := new(sessionRecorder)
+	service := auth.NewService(&memoryUserService{user: user}, plainHasher{}, tokenService, recorder)
+
+	login, err := service.GenerateToken(t.Context(), &requests.LoginRequest{
+		BasicAuth: requests.BasicAuth{Email: user.Email, Password: "hunter2"},
+	})
+	require.NoError(t, err)
+
+	accessClaims, err := tokenService.ParseAccessToken(t.Context(), login.AccessToken)
+	require.NoError(t, err)
+	require.NotEmpty(t, accessClaims.SessionID)
+
+	refreshClaims, err := tokenService.ParseRefreshToken(t.Context(), login.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, accessClaims.SessionID, refreshClaims.SessionID)
+
+	refreshed, err := service.RefreshToken(t.Context(), &requests.RefreshRequest{Token: login.RefreshToken})
+	require.NoError(t, err)
+
+	refreshedClaims, err := tokenService.ParseAccessToken(t.Context(), refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, accessClaims.SessionID, refreshedClaims.SessionID)
+
+	refreshedRefreshClaims, err := tokenService.ParseRefreshToken(t.Context(), refreshed.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, accessClaims.SessionID, refreshedRefreshClaims.SessionID)
+
+	// Both logins were logged with the session
+	assert.Equal(t, []string{accessClaims.SessionID, accessClaims.SessionID}, recorder.sessionIDs)
+}
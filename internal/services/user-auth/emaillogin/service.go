@@ -0,0 +1,167 @@
+// Package emaillogin provides passwordless login with a one-time link or code sent by email.
+package emaillogin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/responses"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/mailer"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/otp"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type userService interface {
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	RegisterPasswordless(ctx context.Context, email string) (models.User, error)
+	VerifyEmail(ctx context.Context, id uuid.UUID) (models.User, error)
+}
+
+type otpService interface {
+	Issue(ctx context.Context, purpose models.OneTimeCodePurpose, destination, deviceID string) (otp.Code, error)
+	VerifyCode(ctx context.Context, purpose models.OneTimeCodePurpose, destination, code, deviceID string) error
+	VerifyLink(ctx context.Context, purpose models.OneTimeCodePurpose, linkToken, deviceID string) (string, error)
+}
+
+type tokenService interface {
+	CreateAccessToken(ctx context.Context, user *models.User) (string, int64, error)
+	CreateRefreshToken(ctx context.Context, user *models.User) (string, error)
+}
+
+type loginRecorder interface {
+	RecordSuccess(ctx context.Context, user *models.User, method models.LoginMethod)
+	RecordFailure(ctx context.Context, userID *uuid.UUID, email string, method models.LoginMethod, reason string)
+}
+
+type Service struct {
+	now           func() time.Time
+	linkURL       string
+	mailer        mailer.Mailer
+	otpService    otpService
+	userService   userService
+	tokenService  tokenService
+	loginRecorder loginRecorder
+}
+
+// NewService creates the service. linkURL is the page that receives the link token in its "token" query parameter.
+func NewService(
+	now func() time.Time,
+	linkURL string,
+	mailer mailer.Mailer,
+	otpService otpService,
+	userService userService,
+	tokenService tokenService,
+	loginRecorder loginRecorder,
+) *Service {
+	return &Service{
+		now:           now,
+		linkURL:       linkURL,
+		mailer:        mailer,
+		otpService:    otpService,
+		userService:   userService,
+		tokenService:  tokenService,
+		loginRecorder: loginRecorder,
+	}
+}
+
+// RequestLogin emails a login link and code to the address. It does not reveal whether an account exists.
+func (s *Service) RequestLogin(ctx context.Context, request *requests.EmailLinkRequest) error {
+	code, err := s.otpService.Issue(ctx, models.OneTimeCodePurposeEmailLogin, request.Email, request.DeviceID)
+	if err != nil {
+		return fmt.Errorf("issue one-time code: %w", err)
+	}
+
+	link, err := url.Parse(s.linkURL)
+	if err != nil {
+		return fmt.Errorf("parse link url: %w", err)
+	}
+
+	query := link.Query()
+	query.Set("token", code.LinkToken)
+	link.RawQuery = query.Encode()
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      request.Email,
+		Subject: "Your sign-in link",
+		Text: fmt.Sprintf(
+			"Use this link to sign in:\n\n%s\n\nOr enter this code: %s\n\nThe link and code expire in %s and work only on the device that requested them.",
+			link.String(), code.Code, code.ExpiresAt.Sub(s.now()).Round(time.Minute),
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("send login email: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyLogin exchanges a link token, or an email and code, for tokens. A user is created on first login.
+func (s *Service) VerifyLogin(ctx context.Context, request *requests.EmailLinkVerifyRequest) (*responses.LoginResponse, error) {
+	email := request.Email
+
+	var err error
+	if request.Token != "" {
+		email, err = s.otpService.VerifyLink(ctx, models.OneTimeCodePurposeEmailLogin, request.Token, request.DeviceID)
+	} else {
+		err = s.otpService.VerifyCode(ctx, models.OneTimeCodePurposeEmailLogin, request.Email, request.Code, request.DeviceID)
+	}
+
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidOneTimeCode) {
+			s.loginRecorder.RecordFailure(ctx, nil, email, models.LoginMethodEmailOTP, models.LoginFailureInvalidCode)
+		}
+
+		return nil, fmt.Errorf("verify one-time code: %w", err)
+	}
+
+	user, err := s.userService.GetUserByEmail(ctx, email)
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		user, err = s.userService.RegisterPasswordless(ctx, email)
+		if err != nil {
+			return nil, fmt.Errorf("register passwordless user: %w", err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("get user by email: %w", err)
+	case !user.IsVerified:
+		// The code proves ownership for the first time, so a password set at registration is dropped
+		user, err = s.userService.VerifyEmail(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("verify email: %w", err)
+		}
+	}
+
+	if user.Status == models.StatusBanned {
+		s.loginRecorder.RecordFailure(ctx, &user.ID, user.Email, models.LoginMethodEmailOTP, models.LoginFailureUserBanned)
+
+		return nil, models.ErrUserBanned
+	}
+
+	// Each login starts a session, which refreshes continue
+	sessionID := token.NewSessionID()
+	ctx = token.WithSession(ctx, sessionID)
+	ctx = slogx.With(ctx, "user_id", user.ID.String(), "session_id", sessionID)
+
+	accessToken, exp, err := s.tokenService.CreateAccessToken(ctx, &user)
+	if err != nil {
+		return nil, fmt.Errorf("create access token: %w", err)
+	}
+
+	refreshToken, err := s.tokenService.CreateRefreshToken(ctx, &user)
+	if err != nil {
+		return nil, fmt.Errorf("create refresh token: %w", err)
+	}
+
+	s.loginRecorder.RecordSuccess(ctx, &user, models.LoginMethodEmailOTP)
+
+	return responses.NewLoginResponse(accessToken, refreshToken, exp), nil
+}
@@ -0,0 +1,188 @@
+package emaillogin_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/mailer"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/emaillogin"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/otp"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+// memoryUserRepository keeps users in a map. Transactions are not rolled back.
+type memoryUserRepository struct {
+	users map[uuid.UUID]models.User
+}
+
+func (r *memoryUserRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (r *memoryUserRepository) Create(_ context.Context, created *models.User) error {
+	created.ID = uuid.New()
+	r.users[created.ID] = *created
+
+	return nil
+}
+
+func (r *memoryUserRepository) GetByID(_ context.Context, id uuid.UUID) (models.User, error) {
+	found, ok := r.users[id]
+	if !ok {
+		return models.User{}, models.ErrUserNotFound
+	}
+
+	return found, nil
+}
+
+func (r *memoryUserRepository) GetUserByEmail(_ context.Context, email string) (models.User, error) {
+	for _, existing := range r.users {
+		if strings.EqualFold(existing.Email, email) {
+			return existing, nil
+		}
+	}
+
+	return models.User{}, models.ErrUserNotFound
+}
+
+func (r *memoryUserRepository) GetByVerifiedPhone(context.Context, string) (models.User, error) {
+	return models.User{}, models.ErrUserNotFound
+}
+
+func (r *memoryUserRepository) CreateUserAndOAuthProvider(ctx context.Context, created *models.User, _ *models.OAuthProviders) error {
+	return r.Create(ctx, created)
+}
+
+func (r *memoryUserRepository) UpdateLastLoginAt(context.Context, uuid.UUID, time.Time) error {
+	return nil
+}
+
+func (r *memoryUserRepository) UpdateStatus(context.Context, uuid.UUID, string) error {
+	return nil
+}
+
+func (r *memoryUserRepository) UpdateRole(context.Context, uuid.UUID, string) error {
+	return nil
+}
+
+func (r *memoryUserRepository) UpdatePasswordHash(context.Context, uuid.UUID, string) error {
+	return nil
+}
+
+func (r *memoryUserRepository) VerifyEmail(_ context.Context, id uuid.UUID) error {
+	verified := r.users[id]
+	verified.IsVerified = true
+	verified.PasswordHash = ""
+	r.users[id] = verified
+
+	return nil
+}
+
+func (r *memoryUserRepository) SetVerifiedPhone(context.Context, uuid.UUID, string, time.Time) error {
+	return nil
+}
+
+type recordingAuditor struct {
+	actions []audit.Action
+}
+
+func (a *recordingAuditor) Record(_ context.Context, _ audit.Actor, action audit.Action, _ audit.Target, _ any) error {
+	a.actions = append(a.actions, action)
+
+	return nil
+}
+
+// acceptingOTPService accepts every code and issues a fixed one.
+type acceptingOTPService struct{}
+
+func (acceptingOTPService) Issue(context.Context, models.OneTimeCodePurpose, string, string) (otp.Code, error) {
+	return otp.Code{Code: "123456", LinkToken: "link", ExpiresAt: now.Add(10 * time.Minute)}, nil
+}
+
+func (acceptingOTPService) VerifyCode(context.Context, models.OneTimeCodePurpose, string, string, string) error {
+	return nil
+}
+
+func (acceptingOTPService) VerifyLink(context.Context, models.OneTimeCodePurpose, string, string) (string, error) {
+	return "", nil
+}
+
+type fakeTokenService struct{}
+
+func (fakeTokenService) CreateAccessToken(context.Context, *models.User) (string, int64, error) {
+	return "access", 0, nil
+}
+
+func (fakeTokenService) CreateRefreshToken(context.Context, *models.User) (string, error) {
+	return "refresh", nil
+}
+
+type discardRecorder struct{}
+
+func (discardRecorder) RecordSuccess(context.Context, *models.User, models.LoginMethod) {}
+
+func (discardRecorder) RecordFailure(context.Context, *uuid.UUID, string, models.LoginMethod, string) {
+}
+
+type memoryMailer struct {
+	messages []mailer.Message
+}
+
+func (m *memoryMailer) Send(_ context.Context, message mailer.Message) error {
+	m.messages = append(m.messages, message)
+
+	return nil
+}
+
+func newService(repository *memoryUserRepository, auditor *recordingAuditor, mail *memoryMailer) *emaillogin.Service {
+	userService := user.NewService(repository, nil, nil, auditor)
+
+	return emaillogin.NewService(func() time.Time { return now }, "https://app.example.com/login", mail,
+		acceptingOTPService{}, userService, fakeTokenService{}, discardRecorder{})
+}
+
+func TestVerifyLoginClearsPasswordOfUnverifiedAccount(t *testing.T) {
+	t.Parallel()
+
+	// Someone registered the address with a password before its owner ever proved it
+	squatted := models.User{ID: uuid.New(), Email: "owner@example.com", PasswordHash: "attacker-hash", Status: models.StatusActive}
+	repository := &memoryUserRepository{users: map[uuid.UUID]models.User{squatted.ID: squatted}}
+	auditor := new(recordingAuditor)
+
+	_, err := newService(repository, auditor, new(memoryMailer)).VerifyLogin(t.Context(), &requests.EmailLinkVerifyRequest{
+		Email:    "owner@example.com",
+		Code:     "123456",
+		DeviceID: "device",
+	})
+	require.NoError(t, err)
+
+	claimed := repository.users[squatted.ID]
+	assert.True(t, claimed.IsVerified)
+	assert.Empty(t, claimed.PasswordHash)
+	assert.Equal(t, []audit.Action{audit.ActionUserEmailVerify}, auditor.actions)
+}
+
+func TestRequestLoginExpiry(t *testing.T) {
+	t.Parallel()
+
+	mail := new(memoryMailer)
+
+	err := newService(&memoryUserRepository{}, new(recordingAuditor), mail).RequestLogin(t.Context(), &requests.EmailLinkRequest{
+		Email:    "owner@example.com",
+		DeviceID: "device",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, mail.messages, 1)
+	assert.Contains(t, mail.messages[0].Text, "expire in 10m0s")
+}
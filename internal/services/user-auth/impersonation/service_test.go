@@ -0,0 +1,159 @@
+package impersonation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/impersonation"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var expiresAt = time.Date(2026, 10, 19, 12, 15, 0, 0, time.UTC)
+
+// journal records the calls of the fakes in order; a failed transaction drops its calls.
+type journal struct {
+	calls []string
+}
+
+type memoryUserService struct {
+	journal *journal
+	users   map[uuid.UUID]models.User
+}
+
+func (s *memoryUserService) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	committed := len(s.journal.calls)
+
+	if err := fn(ctx); err != nil {
+		s.journal.calls = s.journal.calls[:committed]
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *memoryUserService) GetByID(_ context.Context, id uuid.UUID) (models.User, error) {
+	user, ok := s.users[id]
+	if !ok {
+		return models.User{}, models.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+type fakeTokenService struct {
+	journal *journal
+}
+
+func (s *fakeTokenService) ImpersonationTokenExpiry(time.Duration) time.Time {
+	return expiresAt
+}
+
+func (s *fakeTokenService) CreateImpersonationToken(
+	_ context.Context,
+	user *models.User,
+	_ uuid.UUID,
+	_ time.Time,
+) (string, error) {
+	s.journal.calls = append(s.journal.calls, "mint")
+
+	return "token-" + user.ID.String(), nil
+}
+
+type fakeAuditor struct {
+	journal *journal
+	err     error
+}
+
+func (a *fakeAuditor) Record(context.Context, audit.Actor, audit.Action, audit.Target, any) error {
+	if a.err != nil {
+		return a.err
+	}
+
+	a.journal.calls = append(a.journal.calls, "audit")
+
+	return nil
+}
+
+type fixture struct {
+	service *impersonation.Service
+	journal *journal
+	auditor *fakeAuditor
+	admin   models.User
+	user    models.User
+	other   models.User
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		journal: new(journal),
+		admin:   models.User{ID: uuid.New(), Role: models.RoleAdmin},
+		user:    models.User{ID: uuid.New(), Role: models.RoleUser},
+		other:   models.User{ID: uuid.New(), Role: models.RoleAdmin},
+	}
+
+	f.auditor = &fakeAuditor{journal: f.journal}
+
+	users := &memoryUserService{journal: f.journal, users: map[uuid.UUID]models.User{
+		f.admin.ID: f.admin,
+		f.user.ID:  f.user,
+		f.other.ID: f.other,
+	}}
+
+	f.service = impersonation.NewService(15*time.Minute, users, &fakeTokenService{journal: f.journal}, f.auditor)
+
+	return f
+}
+
+func TestImpersonate(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture()
+
+	response, err := f.service.Impersonate(t.Context(), f.admin.ID, f.user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "token-"+f.user.ID.String(), response.AccessToken)
+	assert.Equal(t, expiresAt.Unix(), response.Exp)
+
+	// The impersonation is audited before the token exists
+	assert.Equal(t, []string{"audit", "mint"}, f.journal.calls)
+}
+
+func TestImpersonateRefusals(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name   string
+		target func(f *fixture) uuid.UUID
+	}{
+		{name: "self", target: func(f *fixture) uuid.UUID { return f.admin.ID }},
+		{name: "admin target", target: func(f *fixture) uuid.UUID { return f.other.ID }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newFixture()
+
+			_, err := f.service.Impersonate(t.Context(), f.admin.ID, tt.target(f))
+			require.ErrorIs(t, err, models.ErrImpersonationForbidden)
+			assert.Empty(t, f.journal.calls)
+		})
+	}
+}
+
+func TestImpersonateAuditFailure(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture()
+	f.auditor.err = errors.New("audit log unavailable")
+
+	_, err := f.service.Impersonate(t.Context(), f.admin.ID, f.user.ID)
+	require.ErrorIs(t, err, f.auditor.err)
+	assert.Empty(t, f.journal.calls)
+}
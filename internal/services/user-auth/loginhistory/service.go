@@ -0,0 +1,100 @@
+// Package loginhistory records authentication attempts and exposes a user's login history.
+package loginhistory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/clientinfo"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type loginEventRepository interface {
+	Create(ctx context.Context, event *models.LoginEvent) error
+	ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.LoginEvent, int64, error)
+}
+
+type userService interface {
+	UpdateLastLoginAt(ctx context.Context, id uuid.UUID, lastLoginAt time.Time) error
+}
+
+type loginObserver interface {
+	ObserveLogin(method models.LoginMethod, outcome models.LoginOutcome)
+}
+
+type Service struct {
+	now                  func() time.Time
+	loginEventRepository loginEventRepository
+	userService          userService
+	loginObserver        loginObserver
+}
+
+func NewService(
+	now func() time.Time,
+	loginEventRepository loginEventRepository,
+	userService userService,
+	loginObserver loginObserver,
+) *Service {
+	return &Service{
+		now:                  now,
+		loginEventRepository: loginEventRepository,
+		userService:          userService,
+		loginObserver:        loginObserver,
+	}
+}
+
+// RecordSuccess stores a successful login and updates the user's last login time.
+// Failures are logged rather than returned so that bookkeeping never blocks authentication.
+func (s *Service) RecordSuccess(ctx context.Context, user *models.User, method models.LoginMethod) {
+	now := s.now()
+
+	s.record(ctx, &models.LoginEvent{
+		UserID:    &user.ID,
+		Email:     user.Email,
+		Method:    method,
+		Outcome:   models.LoginOutcomeSuccess,
+		CreatedAt: now,
+	})
+
+	if err := s.userService.UpdateLastLoginAt(ctx, user.ID, now); err != nil {
+		slog.ErrorContext(ctx, "Failed to update last login time", "err", err.Error())
+	}
+}
+
+// RecordFailure stores a failed login. userID is nil when the attempt could not be linked to a user.
+func (s *Service) RecordFailure(ctx context.Context, userID *uuid.UUID, email string, method models.LoginMethod, reason string) {
+	s.record(ctx, &models.LoginEvent{
+		UserID:        userID,
+		Email:         email,
+		Method:        method,
+		Outcome:       models.LoginOutcomeFailure,
+		FailureReason: reason,
+		CreatedAt:     s.now(),
+	})
+}
+
+func (s *Service) ListByUserID(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]models.LoginEvent, int64, error) {
+	events, total, err := s.loginEventRepository.ListByUserID(ctx, userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list login events from repository: %w", err)
+	}
+
+	return events, total, nil
+}
+
+func (s *Service) record(ctx context.Context, event *models.LoginEvent) {
+	client := clientinfo.FromContext(ctx)
+	event.IP = client.IP
+	event.UserAgent = client.UserAgent
+
+	s.loginObserver.ObserveLogin(event.Method, event.Outcome)
+
+	if err := s.loginEventRepository.Create(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "Failed to record login event", "err", err.Error())
+	}
+}
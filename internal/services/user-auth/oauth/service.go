@@ -8,12 +8,22 @@ import (
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/oauth")
+
 type Service struct {
 	idTokenVerifier *oidc.IDTokenVerifier
 	tokenService    tokenService
 	userService     userService
+	loginRecorder   loginRecorder
 }
 
 type userService interface {
@@ -26,14 +36,47 @@ type tokenService interface {
 	CreateRefreshToken(ctx context.Context, user *models.User) (string, error)
 }
 
-func NewService(idTokenVerifier *oidc.IDTokenVerifier, tokenService tokenService, userService userService) *Service {
-	return &Service{idTokenVerifier: idTokenVerifier, tokenService: tokenService, userService: userService}
+type loginRecorder interface {
+	RecordSuccess(ctx context.Context, user *models.User, method models.LoginMethod)
+	RecordFailure(ctx context.Context, userID *uuid.UUID, email string, method models.LoginMethod, reason string)
 }
 
-func (s Service) GoogleOAuth(ctx context.Context, token string) (accessToken, refreshToken string, exp int64, err error) {
+func NewService(
+	idTokenVerifier *oidc.IDTokenVerifier,
+	tokenService tokenService,
+	userService userService,
+	loginRecorder loginRecorder,
+) *Service {
+	return &Service{
+		idTokenVerifier: idTokenVerifier,
+		tokenService:    tokenService,
+		userService:     userService,
+		loginRecorder:   loginRecorder,
+	}
+}
+
+// verify checks the ID token in a span. go-oidc fetches the provider's keys when it sees a new key ID.
+func (s Service) verify(ctx context.Context, token string) (*oidc.IDToken, error) {
+	ctx, span := tracer.Start(ctx, "oidc.Verify", trace.WithAttributes(attribute.String("oidc.provider", "google")))
+	defer span.End()
+
 	payload, err := s.idTokenVerifier.Verify(ctx, token)
 	if err != nil {
-		return "", "", 0, fmt.Errorf("verify google token: %w", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "verification failed")
+
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+func (s Service) GoogleOAuth(ctx context.Context, idToken string) (accessToken, refreshToken string, exp int64, err error) {
+	payload, err := s.verify(ctx, idToken)
+	if err != nil {
+		s.loginRecorder.RecordFailure(ctx, nil, "", models.LoginMethodGoogle, models.LoginFailureInvalidToken)
+
+		return "", "", 0, fmt.Errorf("verify google token: %w", errors.Join(models.ErrInvalidAuthToken, err))
 	}
 
 	var claims struct {
@@ -43,11 +86,15 @@ func (s Service) GoogleOAuth(ctx context.Context, token string) (accessToken, re
 
 	err = payload.Claims(&claims)
 	if err != nil {
-		return "", "", 0, fmt.Errorf("extract claims: %w", err)
+		s.loginRecorder.RecordFailure(ctx, nil, "", models.LoginMethodGoogle, models.LoginFailureInvalidClaims)
+
+		return "", "", 0, fmt.Errorf("extract claims: %w", errors.Join(models.ErrInvalidAuthToken, err))
 	}
 
 	if claims.Email == "" {
-		return "", "", 0, fmt.Errorf("email is empty")
+		s.loginRecorder.RecordFailure(ctx, nil, "", models.LoginMethodGoogle, models.LoginFailureMissingEmail)
+
+		return "", "", 0, fmt.Errorf("google token has no email: %w", models.ErrInvalidAuthToken)
 	}
 
 	user, err := s.userService.GetUserByEmail(ctx, claims.Email)
@@ -64,7 +111,7 @@ func (s Service) GoogleOAuth(ctx context.Context, token string) (accessToken, re
 		oAuthProvider := models.OAuthProviders{
 			UserID:   user.ID,
 			Provider: models.GOOGLE,
-			Token:    token,
+			Token:    idToken,
 		}
 
 		err = s.userService.CreateUserAndOAuthProvider(ctx, &user, &oAuthProvider)
@@ -73,6 +120,17 @@ func (s Service) GoogleOAuth(ctx context.Context, token string) (accessToken, re
 		}
 	}
 
+	if user.Status == models.StatusBanned {
+		s.loginRecorder.RecordFailure(ctx, &user.ID, user.Email, models.LoginMethodGoogle, models.LoginFailureUserBanned)
+
+		return "", "", 0, models.ErrUserBanned
+	}
+
+	// Each login starts a session, which refreshes continue
+	sessionID := token.NewSessionID()
+	ctx = token.WithSession(ctx, sessionID)
+	ctx = slogx.With(ctx, "user_id", user.ID.String(), "session_id", sessionID)
+
 	accessToken, exp, err = s.tokenService.CreateAccessToken(ctx, &user)
 	if err != nil {
 		return "", "", 0, fmt.Errorf("create access token: %w", err)
@@ -83,5 +141,7 @@ func (s Service) GoogleOAuth(ctx context.Context, token string) (accessToken, re
 		return "", "", 0, fmt.Errorf("create refresh token: %w", err)
 	}
 
+	s.loginRecorder.RecordSuccess(ctx, &user, models.LoginMethodGoogle)
+
 	return accessToken, refreshToken, exp, nil
 }
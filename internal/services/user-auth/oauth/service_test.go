@@ -0,0 +1,127 @@
+package oauth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/oauth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer   = "https://accounts.example.com"
+	testClientID = "client-id"
+)
+
+type memoryUserService struct {
+	users map[string]models.User
+}
+
+func (s *memoryUserService) CreateUserAndOAuthProvider(_ context.Context, user *models.User, _ *models.OAuthProviders) error {
+	user.ID = uuid.New()
+	s.users[user.Email] = *user
+
+	return nil
+}
+
+func (s *memoryUserService) GetUserByEmail(_ context.Context, email string) (models.User, error) {
+	user, ok := s.users[email]
+	if !ok {
+		return models.User{}, models.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+type fakeTokenService struct{}
+
+func (fakeTokenService) CreateAccessToken(context.Context, *models.User) (string, int64, error) {
+	return "access", 0, nil
+}
+
+func (fakeTokenService) CreateRefreshToken(context.Context, *models.User) (string, error) {
+	return "refresh", nil
+}
+
+type failureRecorder struct {
+	reasons []string
+}
+
+func (r *failureRecorder) RecordSuccess(context.Context, *models.User, models.LoginMethod) {}
+
+func (r *failureRecorder) RecordFailure(_ context.Context, _ *uuid.UUID, _ string, _ models.LoginMethod, reason string) {
+	r.reasons = append(r.reasons, reason)
+}
+
+func TestGoogleOAuthFailureReasons(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	verifier := oidc.NewVerifier(testIssuer, &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{key.Public()}},
+		&oidc.Config{ClientID: testClientID})
+
+	idToken := func(t *testing.T, claims jwt.MapClaims) string {
+		t.Helper()
+
+		claims["iss"] = testIssuer
+		claims["aud"] = testClientID
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	for _, tt := range []struct {
+		name    string
+		idToken func(t *testing.T) string
+		reason  string
+	}{
+		{
+			name:    "invalid token",
+			idToken: func(*testing.T) string { return "not-a-token" },
+			reason:  models.LoginFailureInvalidToken,
+		},
+		{
+			name:    "invalid claims",
+			idToken: func(t *testing.T) string { return idToken(t, jwt.MapClaims{"email": 42}) },
+			reason:  models.LoginFailureInvalidClaims,
+		},
+		{
+			name:    "missing email",
+			idToken: func(t *testing.T) string { return idToken(t, jwt.MapClaims{"name": "Player"}) },
+			reason:  models.LoginFailureMissingEmail,
+		},
+		{
+			name:    "banned user",
+			idToken: func(t *testing.T) string { return idToken(t, jwt.MapClaims{"email": "banned@example.com"}) },
+			reason:  models.LoginFailureUserBanned,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			users := &memoryUserService{users: map[string]models.User{
+				"banned@example.com": {ID: uuid.New(), Email: "banned@example.com", Status: models.StatusBanned},
+			}}
+			recorder := new(failureRecorder)
+			service := oauth.NewService(verifier, fakeTokenService{}, users, recorder)
+
+			_, _, _, err := service.GoogleOAuth(t.Context(), tt.idToken(t))
+			require.Error(t, err)
+			assert.Equal(t, []string{tt.reason}, recorder.reasons)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+// Package otp issues and verifies short-lived, single-use codes sent to an email address or phone number.
+//
+// Every code comes with a 6-digit code for manual entry and a random link token for one-click
+// links. Both are bound to the device that requested them and can be used only once. Issuing is
+// throttled per destination and per client IP address.
+package otp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/clientinfo"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+const (
+	codeDigits      = 6
+	linkTokenLength = 32
+)
+
+type oneTimeCodeRepository interface {
+	Create(ctx context.Context, code *models.OneTimeCode) error
+	CountByDestinationSince(ctx context.Context, purposes []models.OneTimeCodePurpose, destination string, since time.Time) (int64, error)
+	CountByIPSince(ctx context.Context, purposes []models.OneTimeCodePurpose, ip string, since time.Time) (int64, error)
+	GetLatestActive(ctx context.Context, purpose models.OneTimeCodePurpose, destination string, now time.Time) (models.OneTimeCode, error)
+	GetActiveByLinkTokenHash(
+		ctx context.Context,
+		purpose models.OneTimeCodePurpose,
+		linkTokenHash string,
+		now time.Time,
+	) (models.OneTimeCode, error)
+	UseAttempt(ctx context.Context, id uuid.UUID, maxAttempts int) error
+	Consume(ctx context.Context, id uuid.UUID, consumedAt time.Time) error
+}
+
+type Config struct {
+	// Purposes are the purposes the service issues codes for. Throttles count the codes of all of
+	// them together, so that switching purposes does not get around the limits.
+	Purposes []models.OneTimeCodePurpose
+	// Secret keys the stored hashes, so a database leak does not reveal brute-forceable codes.
+	Secret            []byte
+	TTL               time.Duration
+	MaxAttempts       int
+	ThrottleWindow    time.Duration
+	MaxPerDestination int
+	MaxPerIP          int
+}
+
+// Code is a freshly issued one-time code. It is returned in plain text only once, to be delivered.
+type Code struct {
+	Code      string
+	LinkToken string
+	ExpiresAt time.Time
+}
+
+type Service struct {
+	now                   func() time.Time
+	config                Config
+	oneTimeCodeRepository oneTimeCodeRepository
+}
+
+func NewService(now func() time.Time, config Config, oneTimeCodeRepository oneTimeCodeRepository) *Service {
+	return &Service{
+		now:                   now,
+		config:                config,
+		oneTimeCodeRepository: oneTimeCodeRepository,
+	}
+}
+
+// Issue creates a code for the destination bound to deviceID. It fails with [models.ErrTooManyRequests]
+// if the destination or the client IP address requested too many codes recently.
+func (s *Service) Issue(ctx context.Context, purpose models.OneTimeCodePurpose, destination, deviceID string) (Code, error) {
+	if !slices.Contains(s.config.Purposes, purpose) {
+		return Code{}, fmt.Errorf("purpose %q is not issued by this service", purpose)
+	}
+
+	destination = normalizeDestination(destination)
+	now := s.now()
+	since := now.Add(-s.config.ThrottleWindow)
+	ip := clientinfo.FromContext(ctx).IP
+
+	count, err := s.oneTimeCodeRepository.CountByDestinationSince(ctx, s.config.Purposes, destination, since)
+	if err != nil {
+		return Code{}, fmt.Errorf("count codes by destination: %w", err)
+	}
+	if count >= int64(s.config.MaxPerDestination) {
+		return Code{}, fmt.Errorf("destination throttled: %w", models.ErrTooManyRequests)
+	}
+
+	if ip != "" {
+		count, err = s.oneTimeCodeRepository.CountByIPSince(ctx, s.config.Purposes, ip, since)
+		if err != nil {
+			return Code{}, fmt.Errorf("count codes by ip: %w", err)
+		}
+		if count >= int64(s.config.MaxPerIP) {
+			return Code{}, fmt.Errorf("ip throttled: %w", models.ErrTooManyRequests)
+		}
+	}
+
+	code, err := randomDigits(codeDigits)
+	if err != nil {
+		return Code{}, fmt.Errorf("generate code: %w", err)
+	}
+
+	linkToken, err := randomToken(linkTokenLength)
+	if err != nil {
+		return Code{}, fmt.Errorf("generate link token: %w", err)
+	}
+
+	expiresAt := now.Add(s.config.TTL)
+
+	err = s.oneTimeCodeRepository.Create(ctx, &models.OneTimeCode{
+		Purpose:       purpose,
+		Destination:   destination,
+		CodeHash:      s.hash(string(purpose), destination, code),
+		LinkTokenHash: s.hash(string(purpose), linkToken),
+		DeviceHash:    s.hash(deviceID),
+		IP:            ip,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return Code{}, fmt.Errorf("create code in repository: %w", err)
+	}
+
+	return Code{Code: code, LinkToken: linkToken, ExpiresAt: expiresAt}, nil
+}
+
+// VerifyCode consumes the latest code issued for the destination if it matches and was requested from deviceID.
+func (s *Service) VerifyCode(ctx context.Context, purpose models.OneTimeCodePurpose, destination, code, deviceID string) error {
+	destination = normalizeDestination(destination)
+	now := s.now()
+
+	stored, err := s.oneTimeCodeRepository.GetLatestActive(ctx, purpose, destination, now)
+	if errors.Is(err, models.ErrOneTimeCodeNotFound) {
+		return errors.Join(err, models.ErrInvalidOneTimeCode)
+	} else if err != nil {
+		return fmt.Errorf("get active code from repository: %w", err)
+	}
+
+	// The attempt is spent before comparing, so parallel guesses cannot outrun the limit
+	err = s.oneTimeCodeRepository.UseAttempt(ctx, stored.ID, s.config.MaxAttempts)
+	if errors.Is(err, models.ErrOneTimeCodeAttemptsExhausted) {
+		return errors.Join(err, models.ErrInvalidOneTimeCode)
+	} else if err != nil {
+		return fmt.Errorf("use attempt in repository: %w", err)
+	}
+
+	if !hmac.Equal([]byte(stored.CodeHash), []byte(s.hash(string(purpose), destination, code))) {
+		return fmt.Errorf("code mismatch: %w", models.ErrInvalidOneTimeCode)
+	}
+
+	return s.consume(ctx, &stored, deviceID, now)
+}
+
+// VerifyLink consumes the code with the given link token if it was requested from deviceID and returns its destination.
+func (s *Service) VerifyLink(ctx context.Context, purpose models.OneTimeCodePurpose, linkToken, deviceID string) (string, error) {
+	now := s.now()
+
+	stored, err := s.oneTimeCodeRepository.GetActiveByLinkTokenHash(ctx, purpose, s.hash(string(purpose), linkToken), now)
+	if errors.Is(err, models.ErrOneTimeCodeNotFound) {
+		return "", errors.Join(err, models.ErrInvalidOneTimeCode)
+	} else if err != nil {
+		return "", fmt.Errorf("get code by link token from repository: %w", err)
+	}
+
+	if err := s.consume(ctx, &stored, deviceID, now); err != nil {
+		return "", err
+	}
+
+	return stored.Destination, nil
+}
+
+func (s *Service) consume(ctx context.Context, stored *models.OneTimeCode, deviceID string, now time.Time) error {
+	if !hmac.Equal([]byte(stored.DeviceHash), []byte(s.hash(deviceID))) {
+		return fmt.Errorf("device mismatch: %w", models.ErrInvalidOneTimeCode)
+	}
+
+	err := s.oneTimeCodeRepository.Consume(ctx, stored.ID, now)
+	if errors.Is(err, models.ErrOneTimeCodeUsed) {
+		return errors.Join(err, models.ErrInvalidOneTimeCode)
+	} else if err != nil {
+		return fmt.Errorf("consume code in repository: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeDestination makes differently cased or padded spellings of an email address share
+// one throttle and one code.
+func normalizeDestination(destination string) string {
+	return strings.ToLower(strings.TrimSpace(destination))
+}
+
+func (s *Service) hash(parts ...string) string {
+	mac := hmac.New(sha256.New, s.config.Secret)
+	for _, part := range parts {
+		// The separator keeps ("ab", "c") and ("a", "bc") apart.
+		mac.Write([]byte(part))
+		mac.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomDigits(n int) (string, error) {
+	digits := make([]byte, n)
+	for i := range digits {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("read random digit: %w", err)
+		}
+
+		digits[i] = byte('0' + digit.Int64())
+	}
+
+	return string(digits), nil
+}
+
+func randomToken(n int) (string, error) {
+	token := make([]byte, n)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("read random token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
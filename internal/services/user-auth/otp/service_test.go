@@ -0,0 +1,281 @@
+package otp_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/clientinfo"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/otp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryRepository struct {
+	mu    sync.Mutex
+	codes []models.OneTimeCode
+}
+
+func (r *memoryRepository) Create(_ context.Context, code *models.OneTimeCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	code.ID = uuid.New()
+	r.codes = append(r.codes, *code)
+
+	return nil
+}
+
+func (r *memoryRepository) CountByDestinationSince(
+	_ context.Context,
+	purposes []models.OneTimeCodePurpose,
+	destination string,
+	since time.Time,
+) (int64, error) {
+	return r.count(func(code *models.OneTimeCode) bool {
+		return slices.Contains(purposes, code.Purpose) && code.Destination == destination && !code.CreatedAt.Before(since)
+	}), nil
+}
+
+func (r *memoryRepository) CountByIPSince(
+	_ context.Context,
+	purposes []models.OneTimeCodePurpose,
+	ip string,
+	since time.Time,
+) (int64, error) {
+	return r.count(func(code *models.OneTimeCode) bool {
+		return slices.Contains(purposes, code.Purpose) && code.IP == ip && !code.CreatedAt.Before(since)
+	}), nil
+}
+
+func (r *memoryRepository) GetLatestActive(
+	_ context.Context,
+	purpose models.OneTimeCodePurpose,
+	destination string,
+	now time.Time,
+) (models.OneTimeCode, error) {
+	return r.find(func(code *models.OneTimeCode) bool {
+		return code.Purpose == purpose && code.Destination == destination && code.ConsumedAt == nil && code.ExpiresAt.After(now)
+	})
+}
+
+func (r *memoryRepository) GetActiveByLinkTokenHash(
+	_ context.Context,
+	purpose models.OneTimeCodePurpose,
+	linkTokenHash string,
+	now time.Time,
+) (models.OneTimeCode, error) {
+	return r.find(func(code *models.OneTimeCode) bool {
+		return code.Purpose == purpose && code.LinkTokenHash == linkTokenHash && code.ConsumedAt == nil && code.ExpiresAt.After(now)
+	})
+}
+
+func (r *memoryRepository) UseAttempt(_ context.Context, id uuid.UUID, maxAttempts int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.codes {
+		if r.codes[i].ID == id && r.codes[i].Attempts < maxAttempts {
+			r.codes[i].Attempts++
+			return nil
+		}
+	}
+
+	return models.ErrOneTimeCodeAttemptsExhausted
+}
+
+func (r *memoryRepository) Consume(_ context.Context, id uuid.UUID, consumedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.codes {
+		if r.codes[i].ID == id && r.codes[i].ConsumedAt == nil {
+			r.codes[i].ConsumedAt = &consumedAt
+			return nil
+		}
+	}
+
+	return models.ErrOneTimeCodeUsed
+}
+
+func (r *memoryRepository) count(match func(code *models.OneTimeCode) bool) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for i := range r.codes {
+		if match(&r.codes[i]) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (r *memoryRepository) find(match func(code *models.OneTimeCode) bool) (models.OneTimeCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := len(r.codes) - 1; i >= 0; i-- {
+		if match(&r.codes[i]) {
+			return r.codes[i], nil
+		}
+	}
+
+	return models.OneTimeCode{}, models.ErrOneTimeCodeNotFound
+}
+
+func newTestService(repository *memoryRepository) *otp.Service {
+	now := time.Date(2025, 5, 9, 10, 0, 0, 0, time.UTC)
+
+	return otp.NewService(func() time.Time { return now }, otp.Config{
+		Purposes:          []models.OneTimeCodePurpose{purpose, models.OneTimeCodePurposePhoneVerify},
+		Secret:            []byte("secret"),
+		TTL:               10 * time.Minute,
+		MaxAttempts:       3,
+		ThrottleWindow:    time.Hour,
+		MaxPerDestination: 2,
+		MaxPerIP:          3,
+	}, repository)
+}
+
+const purpose = models.OneTimeCodePurposeEmailLogin
+
+func TestVerifyCode(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(&memoryRepository{})
+	ctx := t.Context()
+
+	code, err := service.Issue(ctx, purpose, "user@example.com", "device")
+	require.NoError(t, err)
+
+	err = service.VerifyCode(ctx, purpose, "user@example.com", code.Code, "other-device")
+	require.ErrorIs(t, err, models.ErrInvalidOneTimeCode, "code is bound to the requesting device")
+
+	require.NoError(t, service.VerifyCode(ctx, purpose, "user@example.com", code.Code, "device"))
+
+	err = service.VerifyCode(ctx, purpose, "user@example.com", code.Code, "device")
+	require.ErrorIs(t, err, models.ErrInvalidOneTimeCode, "code is single-use")
+}
+
+func TestVerifyCodeAttempts(t *testing.T) {
+	t.Parallel()
+
+	repository := &memoryRepository{}
+	service := newTestService(repository)
+	ctx := t.Context()
+
+	code, err := service.Issue(ctx, purpose, "user@example.com", "device")
+	require.NoError(t, err)
+
+	for range 3 {
+		err = service.VerifyCode(ctx, purpose, "user@example.com", "wrong", "device")
+		require.ErrorIs(t, err, models.ErrInvalidOneTimeCode)
+	}
+
+	err = service.VerifyCode(ctx, purpose, "user@example.com", code.Code, "device")
+	require.ErrorIs(t, err, models.ErrInvalidOneTimeCode, "correct code is refused after the last attempt")
+	require.ErrorIs(t, err, models.ErrOneTimeCodeAttemptsExhausted)
+	assert.Equal(t, 3, repository.codes[0].Attempts)
+}
+
+func TestVerifyCodeConcurrentAttempts(t *testing.T) {
+	t.Parallel()
+
+	repository := &memoryRepository{}
+	service := newTestService(repository)
+	ctx := t.Context()
+
+	_, err := service.Issue(ctx, purpose, "user@example.com", "device")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			_ = service.VerifyCode(ctx, purpose, "user@example.com", "wrong", "device")
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, 3, repository.codes[0].Attempts)
+}
+
+func TestVerifyLink(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(&memoryRepository{})
+	ctx := t.Context()
+
+	code, err := service.Issue(ctx, purpose, "user@example.com", "device")
+	require.NoError(t, err)
+
+	_, err = service.VerifyLink(ctx, purpose, code.LinkToken, "other-device")
+	require.ErrorIs(t, err, models.ErrInvalidOneTimeCode)
+
+	_, err = service.VerifyLink(ctx, models.OneTimeCodePurposePhoneVerify, code.LinkToken, "device")
+	require.ErrorIs(t, err, models.ErrInvalidOneTimeCode, "link token is bound to its purpose")
+
+	destination, err := service.VerifyLink(ctx, purpose, code.LinkToken, "device")
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", destination)
+}
+
+func TestNormalizesDestination(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(&memoryRepository{})
+	ctx := t.Context()
+
+	_, err := service.Issue(ctx, purpose, "user@example.com", "device")
+	require.NoError(t, err)
+
+	code, err := service.Issue(ctx, purpose, " User@Example.COM", "device")
+	require.NoError(t, err)
+
+	_, err = service.Issue(ctx, purpose, "USER@example.com", "device")
+	require.ErrorIs(t, err, models.ErrTooManyRequests, "case changes share one throttle")
+
+	require.NoError(t, service.VerifyCode(ctx, purpose, "user@EXAMPLE.com ", code.Code, "device"))
+}
+
+func TestIssueThrottlesAcrossPurposes(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(&memoryRepository{})
+	ctx := t.Context()
+
+	_, err := service.Issue(ctx, purpose, "+84901234567", "device")
+	require.NoError(t, err)
+
+	_, err = service.Issue(ctx, models.OneTimeCodePurposePhoneVerify, "+84901234567", "device")
+	require.NoError(t, err)
+
+	_, err = service.Issue(ctx, purpose, "+84901234567", "device")
+	require.ErrorIs(t, err, models.ErrTooManyRequests)
+
+	_, err = service.Issue(ctx, models.OneTimeCodePurposePhoneLogin, "+84909999999", "device")
+	require.Error(t, err, "purposes the service does not issue are refused")
+}
+
+func TestIssueThrottlesIP(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(&memoryRepository{})
+	ctx := clientinfo.WithInfo(t.Context(), clientinfo.Info{IP: "192.0.2.1"})
+
+	for _, destination := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		_, err := service.Issue(ctx, purpose, destination, "device")
+		require.NoError(t, err)
+	}
+
+	_, err := service.Issue(ctx, purpose, "d@example.com", "device")
+	require.ErrorIs(t, err, models.ErrTooManyRequests)
+
+	_, err = service.Issue(t.Context(), purpose, "d@example.com", "device")
+	require.NoError(t, err, "other clients are not throttled")
+}
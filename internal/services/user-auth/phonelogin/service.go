@@ -0,0 +1,194 @@
+// Package phonelogin provides phone number verification and login with a one-time code sent by SMS.
+package phonelogin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/responses"
+	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/phone"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/sms"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/token"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/user-auth/otp"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type userService interface {
+	GetByVerifiedPhone(ctx context.Context, phone string) (models.User, error)
+	SetVerifiedPhone(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error
+}
+
+type otpService interface {
+	Issue(ctx context.Context, purpose models.OneTimeCodePurpose, destination, deviceID string) (otp.Code, error)
+	VerifyCode(ctx context.Context, purpose models.OneTimeCodePurpose, destination, code, deviceID string) error
+}
+
+type tokenService interface {
+	CreateAccessToken(ctx context.Context, user *models.User) (string, int64, error)
+	CreateRefreshToken(ctx context.Context, user *models.User) (string, error)
+}
+
+type loginRecorder interface {
+	RecordSuccess(ctx context.Context, user *models.User, method models.LoginMethod)
+	RecordFailure(ctx context.Context, userID *uuid.UUID, email string, method models.LoginMethod, reason string)
+}
+
+type Service struct {
+	now           func() time.Time
+	defaultRegion string
+	smsSender     sms.SMSSender
+	otpService    otpService
+	userService   userService
+	tokenService  tokenService
+	loginRecorder loginRecorder
+}
+
+// NewService creates the service. Phone numbers in national format are read as numbers of defaultRegion.
+func NewService(
+	now func() time.Time,
+	defaultRegion string,
+	smsSender sms.SMSSender,
+	otpService otpService,
+	userService userService,
+	tokenService tokenService,
+	loginRecorder loginRecorder,
+) *Service {
+	return &Service{
+		now:           now,
+		defaultRegion: defaultRegion,
+		smsSender:     smsSender,
+		otpService:    otpService,
+		userService:   userService,
+		tokenService:  tokenService,
+		loginRecorder: loginRecorder,
+	}
+}
+
+// RequestVerification sends a code proving that the user owns the phone number.
+func (s *Service) RequestVerification(ctx context.Context, userID uuid.UUID, request *requests.PhoneRequest) error {
+	number, err := phone.Normalize(request.Phone, s.defaultRegion)
+	if err != nil {
+		return fmt.Errorf("normalize phone number: %w", err)
+	}
+
+	owner, err := s.userService.GetByVerifiedPhone(ctx, number)
+	switch {
+	case err == nil && owner.ID != userID:
+		return models.ErrPhoneTaken
+	case err != nil && !errors.Is(err, models.ErrUserNotFound):
+		return fmt.Errorf("get user by verified phone: %w", err)
+	}
+
+	return s.sendCode(ctx, models.OneTimeCodePurposePhoneVerify, number, request.DeviceID)
+}
+
+// Verify stores the phone number as the user's verified number if the code matches.
+func (s *Service) Verify(ctx context.Context, userID uuid.UUID, request *requests.PhoneVerifyRequest) error {
+	number, err := phone.Normalize(request.Phone, s.defaultRegion)
+	if err != nil {
+		return fmt.Errorf("normalize phone number: %w", err)
+	}
+
+	err = s.otpService.VerifyCode(ctx, models.OneTimeCodePurposePhoneVerify, number, request.Code, request.DeviceID)
+	if err != nil {
+		return fmt.Errorf("verify one-time code: %w", err)
+	}
+
+	if err := s.userService.SetVerifiedPhone(ctx, userID, number, s.now()); err != nil {
+		return fmt.Errorf("set verified phone: %w", err)
+	}
+
+	return nil
+}
+
+// RequestLogin sends a login code to the phone number. Nothing is sent, and no error is returned,
+// if no user verified the number: texting unknown numbers would only help SMS pumping.
+func (s *Service) RequestLogin(ctx context.Context, request *requests.PhoneRequest) error {
+	number, err := phone.Normalize(request.Phone, s.defaultRegion)
+	if err != nil {
+		return fmt.Errorf("normalize phone number: %w", err)
+	}
+
+	_, err = s.userService.GetByVerifiedPhone(ctx, number)
+	if errors.Is(err, models.ErrUserNotFound) {
+		slog.InfoContext(ctx, "Phone login requested for unknown number")
+
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get user by verified phone: %w", err)
+	}
+
+	return s.sendCode(ctx, models.OneTimeCodePurposePhoneLogin, number, request.DeviceID)
+}
+
+// VerifyLogin exchanges the phone number and code for tokens.
+func (s *Service) VerifyLogin(ctx context.Context, request *requests.PhoneVerifyRequest) (*responses.LoginResponse, error) {
+	number, err := phone.Normalize(request.Phone, s.defaultRegion)
+	if err != nil {
+		return nil, fmt.Errorf("normalize phone number: %w", err)
+	}
+
+	err = s.otpService.VerifyCode(ctx, models.OneTimeCodePurposePhoneLogin, number, request.Code, request.DeviceID)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidOneTimeCode) {
+			s.loginRecorder.RecordFailure(ctx, nil, "", models.LoginMethodPhoneOTP, models.LoginFailureInvalidCode)
+		}
+
+		return nil, fmt.Errorf("verify one-time code: %w", err)
+	}
+
+	user, err := s.userService.GetByVerifiedPhone(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("get user by verified phone: %w", err)
+	}
+
+	if user.Status == models.StatusBanned {
+		s.loginRecorder.RecordFailure(ctx, &user.ID, user.Email, models.LoginMethodPhoneOTP, models.LoginFailureUserBanned)
+
+		return nil, models.ErrUserBanned
+	}
+
+	// Each login starts a session, which refreshes continue
+	sessionID := token.NewSessionID()
+	ctx = token.WithSession(ctx, sessionID)
+	ctx = slogx.With(ctx, "user_id", user.ID.String(), "session_id", sessionID)
+
+	accessToken, exp, err := s.tokenService.CreateAccessToken(ctx, &user)
+	if err != nil {
+		return nil, fmt.Errorf("create access token: %w", err)
+	}
+
+	refreshToken, err := s.tokenService.CreateRefreshToken(ctx, &user)
+	if err != nil {
+		return nil, fmt.Errorf("create refresh token: %w", err)
+	}
+
+	s.loginRecorder.RecordSuccess(ctx, &user, models.LoginMethodPhoneOTP)
+
+	return responses.NewLoginResponse(accessToken, refreshToken, exp), nil
+}
+
+func (s *Service) sendCode(ctx context.Context, purpose models.OneTimeCodePurpose, number, deviceID string) error {
+	code, err := s.otpService.Issue(ctx, purpose, number, deviceID)
+	if err != nil {
+		return fmt.Errorf("issue one-time code: %w", err)
+	}
+
+	err = s.smsSender.Send(ctx, sms.Message{
+		To:   number,
+		Text: fmt.Sprintf("Your verification code is %s. It expires in %s.", code.Code, code.ExpiresAt.Sub(s.now()).Round(time.Minute)),
+	})
+	if err != nil {
+		return fmt.Errorf("send sms: %w", err)
+	}
+
+	return nil
+}
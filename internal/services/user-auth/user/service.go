@@ -2,52 +2,153 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/dtos/user-auth/requests"
 	models "github.com/game-platform-ai/golang-echo-boilerplate/internal/models/user-auth"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/password"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/services/audit"
 	"github.com/google/uuid"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 //go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
 
 type userRepository interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 	Create(ctx context.Context, user *models.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	GetByVerifiedPhone(ctx context.Context, phone string) (models.User, error)
 	CreateUserAndOAuthProvider(ctx context.Context, user *models.User, oauthProvider *models.OAuthProviders) error
+	UpdateLastLoginAt(ctx context.Context, id uuid.UUID, lastLoginAt time.Time) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+	UpdateRole(ctx context.Context, id uuid.UUID, role string) error
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error
+	VerifyEmail(ctx context.Context, id uuid.UUID) error
+	SetVerifiedPhone(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error
+}
+
+type passwordHasher interface {
+	Hash(ctx context.Context, password string) (string, error)
+	Verify(ctx context.Context, encodedHash, password string) (needsRehash bool, err error)
+}
+
+type passwordPolicy interface {
+	Check(password string, identity ...string) error
+}
+
+// auditor records changes. Entries recorded inside [userRepository.Transaction] commit with the change.
+type auditor interface {
+	Record(ctx context.Context, actor audit.Actor, action audit.Action, target audit.Target, diff any) error
 }
 
 type Service struct {
 	userRepository userRepository
+	passwordHasher passwordHasher
+	passwordPolicy passwordPolicy
+	auditor        auditor
 }
 
-func NewService(userRepository userRepository) *Service {
-	return &Service{userRepository: userRepository}
+func NewService(
+	userRepository userRepository,
+	passwordHasher passwordHasher,
+	passwordPolicy passwordPolicy,
+	auditor auditor,
+) *Service {
+	return &Service{
+		userRepository: userRepository,
+		passwordHasher: passwordHasher,
+		passwordPolicy: passwordPolicy,
+		auditor:        auditor,
+	}
+}
+
+// Transaction runs fn in a transaction, so that changes made with the context passed to fn, and
+// their audit entries, commit together.
+func (s *Service) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.userRepository.Transaction(ctx, fn)
 }
 
 func (s *Service) Register(ctx context.Context, request *requests.RegisterRequest) error {
-	encryptedPassword, err := bcrypt.GenerateFromPassword(
-		[]byte(request.Password),
-		bcrypt.DefaultCost,
-	)
+	_, err := s.CreateUser(ctx, request.Email, request.Name, request.Password, models.RoleUser)
+
+	return err
+}
+
+// CreateUser creates a user with a password and role. Register uses it for sign-ups; operators use
+// it directly to create privileged users.
+func (s *Service) CreateUser(ctx context.Context, email, name, password, role string) (models.User, error) {
+	if !slices.Contains(models.Roles, role) {
+		return models.User{}, models.ErrInvalidRole
+	}
+
+	if err := s.passwordPolicy.Check(password, email, name); err != nil {
+		return models.User{}, fmt.Errorf("check password policy: %w", err)
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(ctx, password)
 	if err != nil {
-		return fmt.Errorf("encrypt password: %w", err)
+		return models.User{}, fmt.Errorf("hash password: %w", err)
 	}
 
-	user := &models.User{
-		Email:        request.Email,
-		FullName:     request.Name,
-		PasswordHash: string(encryptedPassword),
+	user := models.User{
+		Email:        email,
+		FullName:     name,
+		PasswordHash: passwordHash,
+		Role:         role,
 	}
 
-	if err := s.userRepository.Create(ctx, user); err != nil {
-		return fmt.Errorf("create user in repository: %w", err)
+	err = s.userRepository.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Create(ctx, &user); err != nil {
+			return fmt.Errorf("create user in repository: %w", err)
+		}
+
+		diff := audit.Diff{"email": {To: user.Email}, "fullName": {To: user.FullName}}
+		if role != models.RoleUser {
+			diff["role"] = audit.Change{To: role}
+		}
+
+		if err := s.auditor.Record(ctx, audit.ActorFromContext(ctx), audit.ActionUserRegister, userTarget(user.ID), diff); err != nil {
+			return fmt.Errorf("audit user registration: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.User{}, err
 	}
 
-	return nil
+	return user, nil
+}
+
+// RegisterPasswordless creates a user who proved ownership of the email address but has no password.
+func (s *Service) RegisterPasswordless(ctx context.Context, email string) (models.User, error) {
+	user := models.User{
+		Email:         email,
+		IsVerified:    true,
+		LoginProvider: models.LoginProviderEmail,
+	}
+
+	err := s.userRepository.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Create(ctx, &user); err != nil {
+			return fmt.Errorf("create user in repository: %w", err)
+		}
+
+		diff := audit.Diff{"email": {To: user.Email}, "loginProvider": {To: user.LoginProvider}}
+		if err := s.auditor.Record(ctx, audit.ActorFromContext(ctx), audit.ActionUserRegister, userTarget(user.ID), diff); err != nil {
+			return fmt.Errorf("audit user registration: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+
+	return user, nil
 }
 
 func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (models.User, error) {
@@ -68,11 +169,235 @@ func (s *Service) GetUserByEmail(ctx context.Context, email string) (models.User
 	return user, nil
 }
 
-func (s *Service) CreateUserAndOAuthProvider(ctx context.Context, user *models.User, oauthProvider *models.OAuthProviders) error {
-	err := s.userRepository.CreateUserAndOAuthProvider(ctx, user, oauthProvider)
+func (s *Service) GetByVerifiedPhone(ctx context.Context, phone string) (models.User, error) {
+	user, err := s.userRepository.GetByVerifiedPhone(ctx, phone)
 	if err != nil {
-		return fmt.Errorf("create user and oauth provider from repository: %w", err)
+		return models.User{}, fmt.Errorf("get user by verified phone from repository: %w", err)
+	}
+
+	return user, nil
+}
+
+// CreateUserAndOAuthProvider registers a user who signed in with an identity provider.
+func (s *Service) CreateUserAndOAuthProvider(ctx context.Context, user *models.User, oauthProvider *models.OAuthProviders) error {
+	return s.userRepository.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.CreateUserAndOAuthProvider(ctx, user, oauthProvider); err != nil {
+			return fmt.Errorf("create user and oauth provider from repository: %w", err)
+		}
+
+		diff := audit.Diff{
+			"email":         {To: user.Email},
+			"fullName":      {To: user.FullName},
+			"oauthProvider": {To: oauthProvider.Provider},
+		}
+		if err := s.auditor.Record(ctx, audit.ActorFromContext(ctx), audit.ActionUserRegister, userTarget(user.ID), diff); err != nil {
+			return fmt.Errorf("audit user registration: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *Service) UpdateLastLoginAt(ctx context.Context, id uuid.UUID, lastLoginAt time.Time) error {
+	if err := s.userRepository.UpdateLastLoginAt(ctx, id, lastLoginAt); err != nil {
+		return fmt.Errorf("update last login time in repository: %w", err)
 	}
 
 	return nil
 }
+
+func (s *Service) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	if err := s.userRepository.UpdatePasswordHash(ctx, id, passwordHash); err != nil {
+		return fmt.Errorf("update password hash in repository: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the email address of the user verified once its owner proved it with a one-time
+// code or link, and returns the updated user. The password is cleared: it was set by whoever
+// registered the address, who may not own it. The owner can set a new one.
+func (s *Service) VerifyEmail(ctx context.Context, id uuid.UUID) (models.User, error) {
+	var user models.User
+
+	err := s.userRepository.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		user, err = s.userRepository.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get user by id from repository: %w", err)
+		}
+
+		if user.IsVerified {
+			return nil
+		}
+
+		if err := s.userRepository.VerifyEmail(ctx, id); err != nil {
+			return fmt.Errorf("verify email in repository: %w", err)
+		}
+
+		diff := audit.Diff{"isVerified": {From: false, To: true}}
+		if user.PasswordHash != "" {
+			diff["passwordCleared"] = audit.Change{To: true}
+		}
+
+		if err := s.auditor.Record(ctx, audit.ActorFromContext(ctx), audit.ActionUserEmailVerify, userTarget(id), diff); err != nil {
+			return fmt.Errorf("audit email verification: %w", err)
+		}
+
+		user.IsVerified = true
+		user.PasswordHash = ""
+
+		return nil
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+
+	return user, nil
+}
+
+// SetVerifiedPhone stores a phone number whose ownership the user proved. It fails with
+// [models.ErrPhoneTaken] if another user verified the same number.
+func (s *Service) SetVerifiedPhone(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error {
+	owner, err := s.userRepository.GetByVerifiedPhone(ctx, phone)
+	switch {
+	case err == nil && owner.ID != id:
+		return models.ErrPhoneTaken
+	case err != nil && !errors.Is(err, models.ErrUserNotFound):
+		return fmt.Errorf("get user by verified phone from repository: %w", err)
+	}
+
+	return s.userRepository.Transaction(ctx, func(ctx context.Context) error {
+		user, err := s.userRepository.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get user by id from repository: %w", err)
+		}
+
+		if err := s.userRepository.SetVerifiedPhone(ctx, id, phone, verifiedAt); err != nil {
+			return fmt.Errorf("set verified phone in repository: %w", err)
+		}
+
+		diff := audit.Diff{"phone": {From: user.Phone, To: phone}}
+		if err := s.auditor.Record(ctx, audit.ActorFromContext(ctx), audit.ActionUserPhoneVerify, userTarget(id), diff); err != nil {
+			return fmt.Errorf("audit phone verification: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ChangePassword replaces the user's password after confirming the current one.
+func (s *Service) ChangePassword(ctx context.Context, id uuid.UUID, currentPassword, newPassword string) error {
+	user, err := s.userRepository.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get user by id from repository: %w", err)
+	}
+
+	_, err = s.passwordHasher.Verify(ctx, user.PasswordHash, currentPassword)
+	switch {
+	case errors.Is(err, password.ErrMismatchedPassword), errors.Is(err, password.ErrUnsupportedHash):
+		return errors.Join(fmt.Errorf("verify current password: %w", err), models.ErrInvalidPassword)
+	case err != nil:
+		return fmt.Errorf("verify current password: %w", err)
+	}
+
+	return s.setPassword(ctx, &user, newPassword, audit.ActionUserPasswordChange)
+}
+
+// ResetPassword sets a new password without knowing the current one. It is meant for operators.
+func (s *Service) ResetPassword(ctx context.Context, id uuid.UUID, newPassword string) error {
+	user, err := s.userRepository.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get user by id from repository: %w", err)
+	}
+
+	return s.setPassword(ctx, &user, newPassword, audit.ActionUserPasswordReset)
+}
+
+func (s *Service) setPassword(ctx context.Context, user *models.User, newPassword string, action audit.Action) error {
+	if err := s.passwordPolicy.Check(newPassword, user.Email, user.Username, user.FullName); err != nil {
+		return fmt.Errorf("check password policy: %w", err)
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(ctx, newPassword)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+
+	return s.userRepository.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.UpdatePasswordHash(ctx, user.ID, passwordHash); err != nil {
+			return fmt.Errorf("update password hash in repository: %w", err)
+		}
+
+		if err := s.auditor.Record(ctx, audit.ActorFromContext(ctx), action, userTarget(user.ID), nil); err != nil {
+			return fmt.Errorf("audit password change: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *Service) Ban(ctx context.Context, id uuid.UUID) error {
+	return s.setStatus(ctx, id, models.StatusBanned, audit.ActionUserBan)
+}
+
+func (s *Service) Unban(ctx context.Context, id uuid.UUID) error {
+	return s.setStatus(ctx, id, models.StatusActive, audit.ActionUserUnban)
+}
+
+func (s *Service) ChangeRole(ctx context.Context, id uuid.UUID, role string) error {
+	if !slices.Contains(models.Roles, role) {
+		return models.ErrInvalidRole
+	}
+
+	return s.userRepository.Transaction(ctx, func(ctx context.Context) error {
+		user, err := s.userRepository.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get user by id from repository: %w", err)
+		}
+
+		if user.Role == role {
+			return nil
+		}
+
+		if err := s.userRepository.UpdateRole(ctx, id, role); err != nil {
+			return fmt.Errorf("update user role in repository: %w", err)
+		}
+
+		diff := audit.Diff{"role": {From: user.Role, To: role}}
+		if err := s.auditor.Record(ctx, audit.ActorFromContext(ctx), audit.ActionUserRole, userTarget(id), diff); err != nil {
+			return fmt.Errorf("audit user role change: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *Service) setStatus(ctx context.Context, id uuid.UUID, status string, action audit.Action) error {
+	return s.userRepository.Transaction(ctx, func(ctx context.Context) error {
+		user, err := s.userRepository.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get user by id from repository: %w", err)
+		}
+
+		if user.Status == status {
+			return nil
+		}
+
+		if err := s.userRepository.UpdateStatus(ctx, id, status); err != nil {
+			return fmt.Errorf("update user status in repository: %w", err)
+		}
+
+		diff := audit.Diff{"status": {From: user.Status, To: status}}
+		if err := s.auditor.Record(ctx, audit.ActorFromContext(ctx), action, userTarget(id), diff); err != nil {
+			return fmt.Errorf("audit user status change: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func userTarget(id uuid.UUID) audit.Target {
+	return audit.Target{Type: audit.TargetTypeUser, ID: id.String()}
+}
@@ -0,0 +1,84 @@
+package slogx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// asyncWriter queues writes for a goroutine, so that a slow output does not slow down the caller.
+// When the queue is full, writes are dropped and counted instead of blocking.
+type asyncWriter struct {
+	writer io.Writer
+
+	queue   chan []byte
+	dropped atomic.Uint64
+	done    chan struct{}
+
+	// mu keeps Close from closing the queue during a Write.
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newAsyncWriter(writer io.Writer, size int) *asyncWriter {
+	w := &asyncWriter{
+		writer: writer,
+		queue:  make(chan []byte, size),
+		done:   make(chan struct{}),
+	}
+
+	go w.run()
+
+	return w
+}
+
+// Write queues a copy of p. Each call of a [slog.Handler] writes a whole record, so records are
+// dropped whole.
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+
+	select {
+	case w.queue <- append([]byte(nil), p...):
+	default:
+		w.dropped.Add(1)
+	}
+
+	return len(p), nil
+}
+
+// Dropped returns the number of writes dropped so far.
+func (w *asyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close writes the queued records and stops the goroutine. Later writes fail.
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	<-w.done
+
+	return nil
+}
+
+func (w *asyncWriter) run() {
+	defer close(w.done)
+
+	for p := range w.queue {
+		if _, err := w.writer.Write(p); err != nil {
+			// The logger writes here, so report to stderr.
+			fmt.Fprintf(os.Stderr, "write log: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package slogx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// With returns a context whose log records carry the given attributes, in addition to those
+// already added to ctx. The arguments are read like the ones of [slog.Logger.With]. An attribute
+// replaces an earlier one with the same key.
+func With(ctx context.Context, args ...any) context.Context {
+	var record slog.Record
+	record.Add(args...)
+
+	attrs := slices.Clone(attrsFromContext(ctx))
+
+	record.Attrs(func(attr slog.Attr) bool {
+		i := slices.IndexFunc(attrs, func(a slog.Attr) bool { return a.Key == attr.Key })
+		if i < 0 {
+			attrs = append(attrs, attr)
+		} else {
+			attrs[i] = attr
+		}
+
+		return true
+	})
+
+	return context.WithValue(ctx, attrsKey, attrs)
+}
+
+// Attrs returns the attributes added to ctx by [With].
+func Attrs(ctx context.Context) []slog.Attr {
+	return slices.Clone(attrsFromContext(ctx))
+}
+
+var _ slog.Handler = (*attrsHandler)(nil)
+
+// attrsHandler adds the attributes stored by [With] to every record logged with the context.
+type attrsHandler struct {
+	handler slog.Handler
+}
+
+func newAttrsHandler(handler slog.Handler) *attrsHandler {
+	return &attrsHandler{handler: handler}
+}
+
+func (h *attrsHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *attrsHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if attrs := attrsFromContext(ctx); len(attrs) > 0 {
+			record = record.Clone()
+			record.AddAttrs(attrs...)
+		}
+	}
+
+	if err := h.handler.Handle(ctx, record); err != nil {
+		return fmt.Errorf("handle log with context attributes: %w", err)
+	}
+
+	return nil
+}
+
+func (h *attrsHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return newAttrsHandler(h.handler.WithAttrs(attrs))
+}
+
+func (h *attrsHandler) WithGroup(name string) slog.Handler {
+	return newAttrsHandler(h.handler.WithGroup(name))
+}
+
+type attrsKeyType int8
+
+var attrsKey attrsKeyType = 1
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsKey).([]slog.Attr)
+	return attrs
+}
@@ -0,0 +1,46 @@
+package slogx
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWith(t *testing.T) {
+	buffer := new(bytes.Buffer)
+
+	logger := slog.New(newAttrsHandler(slog.NewJSONHandler(buffer, nil)))
+
+	ctx := With(t.Context(), "user_id", "u1", slog.String("ip", "192.0.2.1"))
+	serviceCtx := With(ctx, "user_id", "u2", "order", 7)
+
+	logger.InfoContext(t.Context(), "Without attributes")
+	logger.InfoContext(ctx, "Request")
+	logger.InfoContext(serviceCtx, "Service")
+	logger.InfoContext(ctx, "Request again")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Len(t, lines, 4)
+
+	want := []map[string]any{
+		{"msg": "Without attributes"},
+		{"msg": "Request", "user_id": "u1", "ip": "192.0.2.1"},
+		{"msg": "Service", "user_id": "u2", "ip": "192.0.2.1", "order": float64(7)},
+		{"msg": "Request again", "user_id": "u1", "ip": "192.0.2.1"},
+	}
+
+	for i, line := range lines {
+		var got map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &got))
+
+		delete(got, "time")
+		delete(got, "level")
+
+		assert.Equal(t, want[i], got)
+	}
+}
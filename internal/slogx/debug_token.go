@@ -0,0 +1,73 @@
+package slogx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DebugTokenHeader carries a token from [DebugTokens.Issue] to log a request at DEBUG level.
+const DebugTokenHeader = "X-Debug-Token"
+
+var ErrInvalidDebugToken = errors.New("invalid debug token")
+
+// DebugTokens issues and verifies signed, expiring tokens that raise the log level of the requests
+// carrying them. They are stateless, so a token stays valid until it expires.
+type DebugTokens struct {
+	now    func() time.Time
+	secret []byte
+}
+
+func NewDebugTokens(now func() time.Time, secret []byte) (*DebugTokens, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("secret is empty")
+	}
+
+	return &DebugTokens{now: now, secret: secret}, nil
+}
+
+// Issue returns a token valid for ttl and its expiry time.
+func (t *DebugTokens) Issue(ttl time.Duration) (string, time.Time) {
+	expiresAt := t.now().Add(ttl).Truncate(time.Second)
+
+	payload := binary.BigEndian.AppendUint64(nil, uint64(expiresAt.Unix()))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(t.sign(payload)), expiresAt
+}
+
+func (t *DebugTokens) Verify(token string) error {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return fmt.Errorf("malformed token: %w", ErrInvalidDebugToken)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != 8 {
+		return fmt.Errorf("malformed payload: %w", ErrInvalidDebugToken)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, t.sign(payload)) {
+		return fmt.Errorf("invalid signature: %w", ErrInvalidDebugToken)
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload)), 0)
+	if !t.now().Before(expiresAt) {
+		return fmt.Errorf("expired: %w", ErrInvalidDebugToken)
+	}
+
+	return nil
+}
+
+func (t *DebugTokens) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte("debug-token:"))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
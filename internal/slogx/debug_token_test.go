@@ -0,0 +1,31 @@
+package slogx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugTokens(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tokens, err := NewDebugTokens(func() time.Time { return now }, []byte("secret"))
+	require.NoError(t, err)
+
+	token, expiresAt := tokens.Issue(30 * time.Minute)
+	assert.Equal(t, now.Add(30*time.Minute), expiresAt.UTC())
+	assert.NoError(t, tokens.Verify(token))
+
+	other, err := NewDebugTokens(func() time.Time { return now }, []byte("other"))
+	require.NoError(t, err)
+	assert.ErrorIs(t, other.Verify(token), ErrInvalidDebugToken)
+
+	assert.ErrorIs(t, tokens.Verify("garbage"), ErrInvalidDebugToken)
+
+	now = now.Add(30 * time.Minute)
+	assert.ErrorIs(t, tokens.Verify(token), ErrInvalidDebugToken)
+}
@@ -1,46 +1,200 @@
 package slogx
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/game-platform-ai/golang-echo-boilerplate/internal/config"
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/redact"
 )
 
-func Init(config config.LogConfig) (err error) {
-	writer := io.Writer(os.Stdout)
-	if config.File != "" {
-		const permission = 0o644
+// dropReportInterval is how often dropped records are reported in the logs.
+const dropReportInterval = 10 * time.Second
 
-		writer, err = os.OpenFile(config.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, permission)
-		if err != nil {
-			return fmt.Errorf("open file %s: %w", config.File, err)
-		}
-	}
+// Logging is the logging set up by [Init]. It must be closed on exit to flush buffered records.
+type Logging struct {
+	// Levels change the level of the logs at runtime.
+	Levels *Levels
+
+	files   []*RotatingFile
+	writers []*asyncWriter
 
+	stop     chan struct{}
+	stopOnce sync.Once
+	reported sync.WaitGroup
+}
+
+// Init sets the default logger, writing to the configured outputs.
+func Init(config config.LogConfig) (logging *Logging, err error) {
 	level := slog.LevelDebug
 	if config.Level != "" {
-		if err = level.UnmarshalText([]byte(config.Level)); err != nil {
-			return fmt.Errorf("parse log level %s: %w", config.Level, err)
+		if level, err = ParseLevel(config.Level); err != nil {
+			return nil, err
 		}
 	}
 
 	hostname, err := os.Hostname()
 	if err != nil {
-		return fmt.Errorf("get hostname: %w", err)
+		return nil, fmt.Errorf("get hostname: %w", err)
+	}
+
+	logging = &Logging{Levels: NewLevels(level), stop: make(chan struct{})}
+
+	sinks, err := logging.openSinks(config)
+	if err != nil {
+		logging.Close()
+
+		return nil, err
 	}
 
-	jsonHandler := slog.NewJSONHandler(writer, &slog.HandlerOptions{AddSource: config.AddSource, Level: level})
+	redactHandler := newRedactHandler(&fanoutHandler{sinks: sinks}, redact.New(config.RedactKeys))
 
-	traceHandler := newTraceHandler(jsonHandler)
+	attrsHandler := newAttrsHandler(redactHandler)
 
-	logger := slog.New(traceHandler).
+	traceHandler := newTraceHandler(attrsHandler)
+
+	levelHandler := newLevelHandler(traceHandler, logging.Levels)
+
+	logger := slog.New(levelHandler).
 		With("application", config.Application).
 		With("hostname", hostname)
 
 	slog.SetDefault(logger)
 
-	return nil
+	if len(logging.writers) > 0 {
+		logging.reported.Add(1)
+
+		go logging.reportDrops()
+	}
+
+	return logging, nil
+}
+
+func (l *Logging) openSinks(config config.LogConfig) ([]sink, error) {
+	outputs := config.Outputs
+	if len(outputs) == 0 {
+		outputs = []string{"stdout"}
+		if config.File != "" {
+			outputs = []string{"file"}
+		}
+	}
+
+	sinks := make([]sink, 0, len(outputs))
+	for _, output := range outputs {
+		var (
+			writer    io.Writer
+			format    string
+			levelName string
+		)
+
+		switch output {
+		case "stdout":
+			writer, format, levelName = os.Stdout, config.Format, config.StdoutLevel
+		case "file":
+			file, err := NewRotatingFile(time.Now, config.File, RotationConfig{
+				MaxSize:    config.FileMaxSize,
+				Every:      config.FileRotateEvery,
+				MaxBackups: config.FileMaxBackups,
+				MaxAge:     config.FileMaxAge,
+				Compress:   config.FileCompress,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			l.files = append(l.files, file)
+			writer, format, levelName = file, config.FileFormat, config.FileLevel
+		default:
+			return nil, fmt.Errorf("unknown log output %q", output)
+		}
+
+		if config.BufferSize > 0 {
+			async := newAsyncWriter(writer, config.BufferSize)
+			l.writers = append(l.writers, async)
+			writer = async
+		}
+
+		handler, err := newFormatHandler(format, writer, config.AddSource)
+		if err != nil {
+			return nil, err
+		}
+
+		level := allLevels
+		if levelName != "" {
+			if level, err = ParseLevel(levelName); err != nil {
+				return nil, err
+			}
+		}
+
+		sinks = append(sinks, sink{handler: handler, level: level})
+	}
+
+	return sinks, nil
+}
+
+// Reopen reopens the log files, after logrotate moved them.
+func (l *Logging) Reopen() error {
+	var errs []error
+	for _, file := range l.files {
+		errs = append(errs, file.Reopen())
+	}
+
+	return errors.Join(errs...)
+}
+
+// Dropped returns the number of records dropped because an output could not keep up.
+func (l *Logging) Dropped() uint64 {
+	var dropped uint64
+	for _, writer := range l.writers {
+		dropped += writer.Dropped()
+	}
+
+	return dropped
+}
+
+// Close writes the buffered records and closes the log files. Records logged afterwards go to
+// stderr, so that an exit error is not lost.
+func (l *Logging) Close() error {
+	l.stopOnce.Do(func() { close(l.stop) })
+	l.reported.Wait()
+
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, nil)))
+
+	var errs []error
+	for _, writer := range l.writers {
+		errs = append(errs, writer.Close())
+	}
+
+	for _, file := range l.files {
+		errs = append(errs, file.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// reportDrops logs how many records were dropped since the last report, which is itself dropped
+// only if the outputs are still saturated.
+func (l *Logging) reportDrops() {
+	defer l.reported.Done()
+
+	ticker := time.NewTicker(dropReportInterval)
+	defer ticker.Stop()
+
+	var reported uint64
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			if dropped := l.Dropped(); dropped > reported {
+				slog.Warn("Log records dropped", "count", dropped-reported, "total", dropped)
+				reported = dropped
+			}
+		}
+	}
 }
@@ -0,0 +1,181 @@
+package slogx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Levels holds the minimum log level, which can be changed at runtime, and overrides of it for
+// packages. An override for a package also applies to its subpackages unless they have their own.
+type Levels struct {
+	level slog.LevelVar
+
+	mu        sync.RWMutex
+	overrides map[string]slog.Level
+	// minimum is the lowest of the level and the overrides, so that Enabled stays cheap.
+	minimum slog.LevelVar
+}
+
+func NewLevels(level slog.Level) *Levels {
+	l := &Levels{overrides: make(map[string]slog.Level)}
+	l.level.Set(level)
+	l.minimum.Set(level)
+
+	return l
+}
+
+// Level returns the level of packages without override.
+func (l *Levels) Level() slog.Level {
+	return l.level.Level()
+}
+
+func (l *Levels) SetLevel(level slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.level.Set(level)
+	l.updateMinimum()
+}
+
+// Overrides returns the level of each package with an override, by import path.
+func (l *Levels) Overrides() map[string]slog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return maps.Clone(l.overrides)
+}
+
+// SetOverride sets the level of the package with the given import path, such as
+// "github.com/game-platform-ai/golang-echo-boilerplate/internal/infra/db".
+func (l *Levels) SetOverride(packagePath string, level slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.overrides[packagePath] = level
+	l.updateMinimum()
+}
+
+func (l *Levels) RemoveOverride(packagePath string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.overrides, packagePath)
+	l.updateMinimum()
+}
+
+func (l *Levels) updateMinimum() {
+	minimum := l.level.Level()
+	for _, level := range l.overrides {
+		minimum = min(minimum, level)
+	}
+
+	l.minimum.Set(minimum)
+}
+
+// levelFor returns the level of the package of the function at pc.
+func (l *Levels) levelFor(pc uintptr) slog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if len(l.overrides) == 0 || pc == 0 {
+		return l.level.Level()
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	packagePath := functionPackage(frame.Function)
+
+	// The longest matching path is the most specific override.
+	level, matched := l.level.Level(), ""
+	for overridePath, overrideLevel := range l.overrides {
+		if len(overridePath) > len(matched) &&
+			(packagePath == overridePath || strings.HasPrefix(packagePath, overridePath+"/")) {
+			level, matched = overrideLevel, overridePath
+		}
+	}
+
+	return level
+}
+
+// functionPackage returns the import path of the package of a function named as by
+// [runtime.Frame], e.g. "example.com/a/b.(*T).M" is in "example.com/a/b".
+func functionPackage(function string) string {
+	slash := strings.LastIndexByte(function, '/') + 1
+	if dot := strings.IndexByte(function[slash:], '.'); dot >= 0 {
+		return function[:slash+dot]
+	}
+
+	return function
+}
+
+// ParseLevel parses a level name such as "DEBUG", or an offset such as "INFO+2".
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("parse log level %q: %w", name, err)
+	}
+
+	return level, nil
+}
+
+type debugKeyType int8
+
+var debugKey debugKeyType = 1
+
+// WithDebug makes every record logged with the returned context pass the level checks, to get the
+// full logs of one request.
+func WithDebug(ctx context.Context) context.Context {
+	return context.WithValue(ctx, debugKey, true)
+}
+
+// IsDebug reports whether the context was escalated by [WithDebug].
+func IsDebug(ctx context.Context) bool {
+	debug, _ := ctx.Value(debugKey).(bool)
+
+	return debug
+}
+
+var _ slog.Handler = (*levelHandler)(nil)
+
+// levelHandler filters records by [Levels], unless the context was escalated by [WithDebug].
+type levelHandler struct {
+	handler slog.Handler
+	levels  *Levels
+}
+
+func newLevelHandler(handler slog.Handler, levels *Levels) *levelHandler {
+	return &levelHandler{handler: handler, levels: levels}
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if ctx != nil && IsDebug(ctx) {
+		return h.handler.Enabled(ctx, level)
+	}
+
+	return level >= h.levels.minimum.Level() && h.handler.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, record slog.Record) error {
+	// Enabled only checked the lowest level, the record's package may require a higher one.
+	if (ctx == nil || !IsDebug(ctx)) && record.Level < h.levels.levelFor(record.PC) {
+		return nil
+	}
+
+	if err := h.handler.Handle(ctx, record); err != nil {
+		return fmt.Errorf("handle log with level: %w", err)
+	}
+
+	return nil
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return newLevelHandler(h.handler.WithAttrs(attrs), h.levels)
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return newLevelHandler(h.handler.WithGroup(name), h.levels)
+}
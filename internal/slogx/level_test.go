@@ -0,0 +1,55 @@
+package slogx
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionPackage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"example.com/a/b.(*T).M":  "example.com/a/b",
+		"example.com/a/b.F.func1": "example.com/a/b",
+		"example.com/a/b-c.F":     "example.com/a/b-c",
+		"main.main":               "main",
+	}
+
+	for function, want := range tests {
+		assert.Equal(t, want, functionPackage(function), function)
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	t.Parallel()
+
+	const thisPackage = "github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+
+	buffer := new(bytes.Buffer)
+	levels := NewLevels(slog.LevelInfo)
+	logger := slog.New(newLevelHandler(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}), levels))
+
+	logger.Debug("hidden by default level")
+
+	levels.SetOverride("github.com/game-platform-ai/golang-echo-boilerplate/internal", slog.LevelWarn)
+	levels.SetOverride(thisPackage, slog.LevelDebug)
+	logger.Debug("shown by package override")
+
+	levels.RemoveOverride(thisPackage)
+	logger.Info("hidden by parent package override")
+	logger.DebugContext(WithDebug(t.Context()), "shown by escalation")
+
+	levels.RemoveOverride("github.com/game-platform-ai/golang-echo-boilerplate/internal")
+	levels.SetLevel(slog.LevelError)
+	logger.Warn("hidden by runtime level")
+
+	output := buffer.String()
+	assert.Equal(t, 2, strings.Count(output, "\n"), output)
+	assert.Contains(t, output, "shown by package override")
+	assert.Contains(t, output, "shown by escalation")
+	assert.Empty(t, levels.Overrides())
+}
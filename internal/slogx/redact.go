@@ -0,0 +1,88 @@
+package slogx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/game-platform-ai/golang-echo-boilerplate/internal/pkg/redact"
+)
+
+var _ slog.Handler = (*redactHandler)(nil)
+
+// redactHandler redacts attributes with sensitive keys, JSON web tokens in messages and strings, and
+// secrets in JSON documents logged as [json.RawMessage].
+type redactHandler struct {
+	handler  slog.Handler
+	redactor *redact.Redactor
+}
+
+func newRedactHandler(handler slog.Handler, redactor *redact.Redactor) *redactHandler {
+	return &redactHandler{handler: handler, redactor: redactor}
+}
+
+func (h *redactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *redactHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, h.redactor.String(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(h.redactAttr(attr))
+
+		return true
+	})
+
+	if err := h.handler.Handle(ctx, redacted); err != nil {
+		return fmt.Errorf("handle log with redaction: %w", err)
+	}
+
+	return nil
+}
+
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = h.redactAttr(attr)
+	}
+
+	return newRedactHandler(h.handler.WithAttrs(redacted), h.redactor)
+}
+
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return newRedactHandler(h.handler.WithGroup(name), h.redactor)
+}
+
+func (h *redactHandler) redactAttr(attr slog.Attr) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Value.Kind() == slog.KindGroup {
+		group := attr.Value.Group()
+
+		redacted := make([]slog.Attr, len(group))
+		for i, item := range group {
+			redacted[i] = h.redactAttr(item)
+		}
+
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(redacted...)}
+	}
+
+	if h.redactor.Key(attr.Key) {
+		return slog.String(attr.Key, redact.Placeholder)
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, h.redactor.String(attr.Value.String()))
+	case slog.KindAny:
+		switch value := attr.Value.Any().(type) {
+		case json.RawMessage:
+			return slog.Any(attr.Key, json.RawMessage(h.redactor.JSON(value)))
+		case error:
+			return slog.String(attr.Key, h.redactor.String(value.Error()))
+		}
+	}
+
+	return attr
+}
@@ -0,0 +1,67 @@
+package slogx
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/google/uuid"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the ID of a request between services and back to the client.
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+// ValidRequestID reports whether id is safe to log and to echo in a header: 1 to 128 letters,
+// digits or any of "-_.:/+=".
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':', r == '/', r == '+', r == '=':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// TraceIDFromRequestID converts a request ID written as a UUID or as 32 hex digits to a trace ID,
+// so that a caller's ID can become the trace ID. Other IDs are only logged.
+func TraceIDFromRequestID(id string) (oteltrace.TraceID, bool) {
+	var traceID oteltrace.TraceID
+
+	if len(id) == 2*len(traceID) {
+		if _, err := hex.Decode(traceID[:], []byte(id)); err != nil {
+			return oteltrace.TraceID{}, false
+		}
+	} else if parsed, err := uuid.Parse(id); err == nil && len(id) == 36 {
+		traceID = oteltrace.TraceID(parsed)
+	} else {
+		return oteltrace.TraceID{}, false
+	}
+
+	return traceID, traceID.IsValid()
+}
+
+type requestIDKeyType int8
+
+var requestIDKey requestIDKeyType = 1
+
+// WithRequestID stores the ID of the request, which [TraceStarter.Start] logs and may use as trace ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the ID of the request stored by [WithRequestID].
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey).(string)
+
+	return id, ok
+}
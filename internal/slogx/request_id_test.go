@@ -0,0 +1,63 @@
+package slogx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidRequestID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"":                                     false,
+		"abc-123_XYZ.v1:a/b+c=":                true,
+		"0192a6f0-7a1b-7c3d-8e4f-5a6b7c8d9e0f": true,
+		"with space":                           false,
+		"new\nline":                            false,
+		"quote\"":                              false,
+		"ünicode":                              false,
+		strings.Repeat("a", 128):               true,
+		strings.Repeat("a", 129):               false,
+	}
+
+	for id, want := range tests {
+		assert.Equal(t, want, ValidRequestID(id), id)
+	}
+}
+
+func TestTraceIDFromRequestID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"0192a6f0-7a1b-7c3d-8e4f-5a6b7c8d9e0f":   "0192a6f07a1b7c3d8e4f5a6b7c8d9e0f",
+		"0192A6F07A1B7C3D8E4F5A6B7C8D9E0F":       "0192a6f07a1b7c3d8e4f5a6b7c8d9e0f",
+		"{0192a6f0-7a1b-7c3d-8e4f-5a6b7c8d9e0f}": "",
+		"00000000000000000000000000000000":       "",
+		"0192a6f07a1b7c3d8e4f5a6b7c8d9e0g":       "",
+		"order-42":                               "",
+	}
+
+	for requestID, want := range tests {
+		traceID, ok := TraceIDFromRequestID(requestID)
+		assert.Equal(t, want != "", ok, requestID)
+
+		if ok {
+			assert.Equal(t, want, traceID.String(), requestID)
+		}
+	}
+}
+
+func TestTraceWithRequestID(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewTraceStarter(nil)
+
+	ctx, err := tracer.Start(WithRequestID(t.Context(), "0192a6f0-7a1b-7c3d-8e4f-5a6b7c8d9e0f"))
+	assert.NoError(t, err)
+
+	trace, ok := traceFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "0192a6f07a1b7c3d8e4f5a6b7c8d9e0f", trace.trace)
+}
@@ -0,0 +1,339 @@
+package slogx
+
+import (
+	"cmp"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	filePermission      = 0o644
+	backupTimeFormat    = "20060102T150405.000"
+	compressedBackupExt = ".gz"
+)
+
+// RotationConfig tells when a [RotatingFile] is rotated and how long rotated files are kept. Zero
+// values disable the corresponding rule.
+type RotationConfig struct {
+	MaxSize    int64
+	Every      time.Duration
+	MaxBackups int
+	MaxAge     time.Duration
+	Compress   bool
+}
+
+// RotatingFile appends to a file, which it renames with a timestamp suffix and replaces when it
+// grows too large or too old. Rotated files are optionally gzipped and deleted by count and age in
+// the background.
+type RotatingFile struct {
+	now    func() time.Time
+	path   string
+	config RotationConfig
+
+	mu sync.Mutex
+	// file is nil after a failed rotation or reopen, and opened again by the next write.
+	file     *os.File
+	closed   bool
+	size     int64
+	openedAt time.Time
+
+	// cleanup serializes the compression and deletion of rotated files.
+	cleanup sync.Mutex
+	pending sync.WaitGroup
+}
+
+func NewRotatingFile(now func() time.Time, path string, config RotationConfig) (*RotatingFile, error) {
+	f := &RotatingFile{now: now, path: path, config: config}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+
+	if f.file != nil && f.shouldRotate(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			// Records keep going to the current file rather than being lost.
+			fmt.Fprintf(os.Stderr, "rotate log file: %v\n", err)
+		}
+	}
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	if err != nil {
+		return n, fmt.Errorf("write log file: %w", err)
+	}
+
+	return n, nil
+}
+
+// Reopen closes and reopens the file, for tools such as logrotate that move it away.
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+
+	if f.file != nil {
+		err := f.file.Close()
+		f.file = nil
+
+		if err != nil {
+			return fmt.Errorf("close log file: %w", err)
+		}
+	}
+
+	return f.open()
+}
+
+// Close closes the file after the pending cleanup of rotated files.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.pending.Wait()
+
+	f.closed = true
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	if err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+
+	return nil
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermission)
+	if err != nil {
+		return fmt.Errorf("open file %s: %w", f.path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return fmt.Errorf("stat file %s: %w", f.path, err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+
+	return nil
+}
+
+func (f *RotatingFile) shouldRotate(writeSize int64) bool {
+	if f.size == 0 {
+		return false
+	}
+
+	if f.config.MaxSize > 0 && f.size+writeSize > f.config.MaxSize {
+		return true
+	}
+
+	return f.config.Every > 0 && f.now().Sub(f.openedAt) >= f.config.Every
+}
+
+// rotate renames the file to a backup and opens a new one. If the rename fails, the file is opened
+// again under its path, and if that fails too, the next write retries.
+func (f *RotatingFile) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+
+	if err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+
+	backup := f.backupName()
+	if err := os.Rename(f.path, backup); err != nil {
+		return errors.Join(fmt.Errorf("rename log file: %w", err), f.open())
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.pending.Add(1)
+
+	go func() {
+		defer f.pending.Done()
+
+		f.cleanup.Lock()
+		defer f.cleanup.Unlock()
+
+		if err := f.cleanBackups(backup); err != nil {
+			// The logger may be the one writing to this file, so report to stderr.
+			fmt.Fprintf(os.Stderr, "clean rotated log files: %v\n", err)
+		}
+	}()
+
+	return nil
+}
+
+// backupName returns an unused name for a backup. Backups rotated within the same millisecond get a
+// counter suffix, so that they do not replace one another.
+func (f *RotatingFile) backupName() string {
+	base := f.path + "." + f.now().UTC().Format(backupTimeFormat)
+
+	for i := 0; ; i++ {
+		name := base
+		if i > 0 {
+			name += "-" + strconv.Itoa(i)
+		}
+
+		if !exists(name) && !exists(name+compressedBackupExt) {
+			return name
+		}
+	}
+}
+
+func exists(name string) bool {
+	_, err := os.Lstat(name)
+
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// backupOrder parses the timestamp and counter of a backup name without the file path, such as
+// "20250101T120000.000-1.gz". It reports false for names that are not backups.
+func backupOrder(suffix string) (time.Time, int, bool) {
+	suffix = strings.TrimSuffix(suffix, compressedBackupExt)
+
+	counter := 0
+	if stamp, number, found := strings.Cut(suffix, "-"); found {
+		n, err := strconv.Atoi(number)
+		if err != nil || n < 1 {
+			return time.Time{}, 0, false
+		}
+
+		suffix, counter = stamp, n
+	}
+
+	stamp, err := time.Parse(backupTimeFormat, suffix)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+
+	return stamp, counter, true
+}
+
+// cleanBackups compresses the new backup and deletes the backups beyond the limits.
+func (f *RotatingFile) cleanBackups(backup string) error {
+	var errs []error
+
+	if f.config.Compress {
+		if err := compressFile(backup); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	backups, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return fmt.Errorf("list rotated log files: %w", err)
+	}
+
+	backups = slices.DeleteFunc(backups, func(name string) bool {
+		_, _, ok := backupOrder(strings.TrimPrefix(name, f.path+"."))
+
+		return !ok
+	})
+
+	// Oldest first, by timestamp and then counter.
+	slices.SortFunc(backups, func(a, b string) int {
+		timeA, counterA, _ := backupOrder(strings.TrimPrefix(a, f.path+"."))
+		timeB, counterB, _ := backupOrder(strings.TrimPrefix(b, f.path+"."))
+
+		if c := timeA.Compare(timeB); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(counterA, counterB)
+	})
+
+	for i, name := range backups {
+		expired := false
+		if f.config.MaxAge > 0 {
+			if info, err := os.Stat(name); err == nil && f.now().Sub(info.ModTime()) > f.config.MaxAge {
+				expired = true
+			}
+		}
+
+		if (f.config.MaxBackups > 0 && i < len(backups)-f.config.MaxBackups) || expired {
+			if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("remove %s: %w", name, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func compressFile(name string) (err error) {
+	source, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", name, err)
+	}
+	defer source.Close()
+
+	target, err := os.OpenFile(name+compressedBackupExt, os.O_CREATE|os.O_EXCL|os.O_WRONLY, filePermission)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name+compressedBackupExt, err)
+	}
+
+	defer func() {
+		if err != nil {
+			target.Close()
+			os.Remove(target.Name())
+		}
+	}()
+
+	writer := gzip.NewWriter(target)
+	if _, err := io.Copy(writer, source); err != nil {
+		return fmt.Errorf("compress %s: %w", name, err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("compress %s: %w", name, err)
+	}
+
+	if err := target.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", target.Name(), err)
+	}
+
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("remove %s: %w", name, err)
+	}
+
+	return nil
+}
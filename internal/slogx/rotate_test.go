@@ -0,0 +1,150 @@
+package slogx
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	file, err := NewRotatingFile(func() time.Time { return now }, path, RotationConfig{
+		MaxSize:    10,
+		Every:      time.Hour,
+		MaxBackups: 2,
+		Compress:   true,
+	})
+	require.NoError(t, err)
+
+	write := func(line string) {
+		_, err := file.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	write("first\n")
+	write("second\n") // over 10 bytes: rotated
+	now = now.Add(time.Second)
+	write("third\n") // over 10 bytes: rotated
+	now = now.Add(time.Hour)
+	write("4\n") // an hour old: rotated
+	now = now.Add(time.Second)
+	write("5\n")
+
+	require.NoError(t, file.Close())
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "4\n5\n", string(current))
+
+	// The oldest of three backups was deleted.
+	backups, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		path + ".20250101T120001.000.gz",
+		path + ".20250101T130001.000.gz",
+	}, backups)
+
+	assert.Equal(t, "second\n", readGzip(t, backups[0]))
+	assert.Equal(t, "third\n", readGzip(t, backups[1]))
+}
+
+func TestRotatingFileSameMillisecond(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	file, err := NewRotatingFile(func() time.Time { return now }, path, RotationConfig{MaxSize: 3, MaxBackups: 2})
+	require.NoError(t, err)
+
+	for _, line := range []string{"a\n", "b\n", "c\n", "d\n"} {
+		_, err := file.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, file.Close())
+
+	// Three backups within the same millisecond, of which the oldest was deleted.
+	backups, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{path + ".20250101T120000.000-1", path + ".20250101T120000.000-2"}, backups)
+
+	content, err := os.ReadFile(path + ".20250101T120000.000-2")
+	require.NoError(t, err)
+	assert.Equal(t, "c\n", string(content))
+}
+
+func TestRotatingFileFailedRotation(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	file, err := NewRotatingFile(time.Now, path, RotationConfig{MaxSize: 3})
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte("a\n"))
+	require.NoError(t, err)
+
+	// The rename fails, as the file is gone.
+	require.NoError(t, os.Remove(path))
+
+	_, err = file.Write([]byte("b\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "b\n", string(current))
+
+	_, err = file.Write([]byte("c\n"))
+	require.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestRotatingFileReopen(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	file, err := NewRotatingFile(time.Now, path, RotationConfig{})
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte("before\n"))
+	require.NoError(t, err)
+
+	// As logrotate does before signaling.
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, file.Reopen())
+
+	_, err = file.Write([]byte("after\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "after\n", string(current))
+}
+
+func readGzip(t *testing.T, name string) string {
+	t.Helper()
+
+	file, err := os.Open(name)
+	require.NoError(t, err)
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	return string(content)
+}
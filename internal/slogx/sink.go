@@ -0,0 +1,177 @@
+package slogx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+)
+
+// allLevels lets a handler take every record, the filtering being done by [Levels] and sinks.
+const allLevels = slog.Level(math.MinInt)
+
+// sink is an output with its format and the lowest level it takes.
+type sink struct {
+	handler slog.Handler
+	level   slog.Level
+}
+
+// newFormatHandler returns a handler writing records to w in the format: "json", "logfmt" or "pretty".
+func newFormatHandler(format string, w io.Writer, addSource bool) (slog.Handler, error) {
+	options := &slog.HandlerOptions{AddSource: addSource, Level: allLevels}
+
+	switch format {
+	case "", "json":
+		return slog.NewJSONHandler(w, options), nil
+	case "logfmt":
+		return slog.NewTextHandler(w, options), nil
+	case "pretty":
+		return newPrettyHandler(w, options), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+var _ slog.Handler = (*fanoutHandler)(nil)
+
+// fanoutHandler passes each record to the sinks whose level it reaches.
+type fanoutHandler struct {
+	sinks []sink
+}
+
+func (h *fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, sink := range h.sinks {
+		if level >= sink.level && sink.handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *fanoutHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+
+	for _, sink := range h.sinks {
+		if record.Level >= sink.level && sink.handler.Enabled(ctx, record.Level) {
+			if err := sink.handler.Handle(ctx, record.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("handle log in sinks: %w", err)
+	}
+
+	return nil
+}
+
+func (h *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h *fanoutHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+func (h *fanoutHandler) with(apply func(slog.Handler) slog.Handler) *fanoutHandler {
+	sinks := make([]sink, len(h.sinks))
+	for i, sink := range h.sinks {
+		sinks[i] = sink
+		sinks[i].handler = apply(sink.handler)
+	}
+
+	return &fanoutHandler{sinks: sinks}
+}
+
+const (
+	colorReset  = "\033[0m"
+	colorGray   = "\033[90m"
+	colorCyan   = "\033[36m"
+	colorYellow = "\033[33m"
+	colorRed    = "\033[31m"
+)
+
+var _ slog.Handler = (*prettyHandler)(nil)
+
+// prettyHandler writes a colored, human-friendly line per record for local development: the time,
+// the level and the message, followed by the attributes in logfmt.
+type prettyHandler struct {
+	w io.Writer
+
+	// attrs writes the attributes of a record to buffer. It is shared by the handlers derived from
+	// the same root, like mu.
+	attrs  slog.Handler
+	buffer *bytes.Buffer
+	mu     *sync.Mutex
+}
+
+func newPrettyHandler(w io.Writer, options *slog.HandlerOptions) *prettyHandler {
+	buffer := new(bytes.Buffer)
+
+	attrsOptions := *options
+	attrsOptions.ReplaceAttr = func(groups []string, attr slog.Attr) slog.Attr {
+		if len(groups) == 0 && (attr.Key == slog.TimeKey || attr.Key == slog.LevelKey || attr.Key == slog.MessageKey) {
+			return slog.Attr{}
+		}
+
+		return attr
+	}
+
+	return &prettyHandler{w: w, attrs: slog.NewTextHandler(buffer, &attrsOptions), buffer: buffer, mu: new(sync.Mutex)}
+}
+
+func (h *prettyHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.attrs.Enabled(ctx, level)
+}
+
+func (h *prettyHandler) Handle(ctx context.Context, record slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.buffer.Reset()
+	if err := h.attrs.Handle(ctx, record); err != nil {
+		return fmt.Errorf("format log attributes: %w", err)
+	}
+
+	color, level := prettyLevel(record.Level)
+
+	line := fmt.Sprintf("%s%s%s %s%s%s %s", colorGray, record.Time.Format(time.TimeOnly+".000"), colorReset,
+		color, level, colorReset, record.Message)
+	if attrs := bytes.TrimSpace(h.buffer.Bytes()); len(attrs) > 0 {
+		line += " " + string(attrs)
+	}
+
+	if _, err := io.WriteString(h.w, line+"\n"); err != nil {
+		return fmt.Errorf("write pretty log: %w", err)
+	}
+
+	return nil
+}
+
+func (h *prettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &prettyHandler{w: h.w, attrs: h.attrs.WithAttrs(attrs), buffer: h.buffer, mu: h.mu}
+}
+
+func (h *prettyHandler) WithGroup(name string) slog.Handler {
+	return &prettyHandler{w: h.w, attrs: h.attrs.WithGroup(name), buffer: h.buffer, mu: h.mu}
+}
+
+func prettyLevel(level slog.Level) (string, string) {
+	switch {
+	case level < slog.LevelInfo:
+		return colorGray, "DBG"
+	case level < slog.LevelWarn:
+		return colorCyan, "INF"
+	case level < slog.LevelError:
+		return colorYellow, "WRN"
+	default:
+		return colorRed, "ERR"
+	}
+}
@@ -0,0 +1,88 @@
+package slogx
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanoutHandler(t *testing.T) {
+	t.Parallel()
+
+	info, debug := new(bytes.Buffer), new(bytes.Buffer)
+
+	logger := slog.New(&fanoutHandler{sinks: []sink{
+		{handler: slog.NewJSONHandler(info, &slog.HandlerOptions{Level: allLevels}), level: slog.LevelInfo},
+		{handler: slog.NewTextHandler(debug, &slog.HandlerOptions{Level: allLevels}), level: allLevels},
+	}}).With("key", "value")
+
+	logger.Debug("details")
+	logger.Info("summary")
+
+	assert.Equal(t, 1, strings.Count(info.String(), "\n"))
+	assert.Contains(t, info.String(), `"msg":"summary","key":"value"`)
+
+	assert.Equal(t, 2, strings.Count(debug.String(), "\n"))
+	assert.Contains(t, debug.String(), "msg=details key=value")
+}
+
+func TestPrettyHandler(t *testing.T) {
+	t.Parallel()
+
+	buffer := new(bytes.Buffer)
+
+	logger := slog.New(newPrettyHandler(buffer, &slog.HandlerOptions{Level: allLevels})).WithGroup("http")
+	logger.Warn("Slow request", "path", "/login")
+	logger.Info("No attributes")
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], colorYellow+"WRN"+colorReset+" Slow request http.path=/login")
+	assert.True(t, strings.HasSuffix(lines[1], colorCyan+"INF"+colorReset+" No attributes"), lines[1])
+}
+
+// blockingWriter blocks writes until released.
+type blockingWriter struct {
+	release chan struct{}
+
+	mu     sync.Mutex
+	writes []string
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.writes = append(w.writes, string(p))
+
+	return len(p), nil
+}
+
+func TestAsyncWriterDrops(t *testing.T) {
+	t.Parallel()
+
+	target := &blockingWriter{release: make(chan struct{})}
+	writer := newAsyncWriter(target, 1)
+
+	// The first write may be taken by the goroutine or queued, so write until one is dropped.
+	for writer.Dropped() == 0 {
+		_, err := writer.Write([]byte("record"))
+		assert.NoError(t, err)
+	}
+
+	close(target.release)
+	assert.NoError(t, writer.Close())
+
+	_, err := writer.Write([]byte("late"))
+	assert.Error(t, err)
+
+	assert.Equal(t, uint64(1), writer.Dropped())
+	assert.NotEmpty(t, target.writes)
+	assert.LessOrEqual(t, len(target.writes), 2)
+}
@@ -0,0 +1,69 @@
+package slogx
+
+import (
+	"context"
+	"crypto/rand"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/game-platform-ai/golang-echo-boilerplate/internal/slogx"
+
+// StartSpan starts a span nested in the active one. Records logged with the returned context carry
+// its ID and its parent's ID, and end logs a "Span" record with the name, start and duration, so
+// that a request's timeline can be rebuilt from the logs alone. The span is also an OpenTelemetry
+// span sharing the same ID when tracing is enabled.
+//
+// Outside of a trace started by [TraceStarter], StartSpan starts a new one.
+func StartSpan(ctx context.Context, name string) (context.Context, func()) {
+	parentSpanID := oteltrace.SpanContextFromContext(ctx).SpanID()
+
+	ctx, otelSpan := otel.Tracer(tracerName).Start(ctx, name)
+
+	// Without a tracer provider the span context is the parent's or empty.
+	spanContext := otelSpan.SpanContext()
+
+	spanID := spanContext.SpanID()
+	if !spanID.IsValid() || spanID == parentSpanID {
+		spanID = newSpanID()
+	}
+
+	t, ok := traceFromContext(ctx)
+	if !ok {
+		traceID := spanContext.TraceID()
+		if !traceID.IsValid() {
+			_, _ = rand.Read(traceID[:])
+		}
+
+		t = trace{trace: traceID.String(), index: &atomic.Int64{}}
+	}
+
+	t.parent = t.span
+	t.span = spanID.String()
+	ctx = withTrace(ctx, t)
+
+	start := time.Now()
+
+	return ctx, func() {
+		otelSpan.End()
+
+		slog.InfoContext(ctx, "Span", slog.Group("span",
+			slog.String("name", name),
+			slog.Time("start", start),
+			slog.Duration("duration", time.Since(start)),
+		))
+	}
+}
+
+func newSpanID() oteltrace.SpanID {
+	var id oteltrace.SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+
+	return id
+}
@@ -0,0 +1,93 @@
+package slogx
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSpanLog struct {
+	Msg   string `json:"msg"`
+	Trace struct {
+		Trace  string `json:"trace"`
+		Index  int64  `json:"index"`
+		Span   string `json:"span"`
+		Parent string `json:"parent"`
+	} `json:"trace"`
+	Span struct {
+		Name     string        `json:"name"`
+		Start    time.Time     `json:"start"`
+		Duration time.Duration `json:"duration"`
+	} `json:"span"`
+}
+
+func TestStartSpan(t *testing.T) {
+	buffer := new(bytes.Buffer)
+
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(newTraceHandler(slog.NewJSONHandler(buffer, nil))))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	tracer := NewTraceStarter(func() (uuid.UUID, error) {
+		return uuid.MustParse("11111111-1111-1111-1111-111111111111"), nil
+	})
+
+	ctx, err := tracer.Start(t.Context())
+	require.NoError(t, err)
+
+	slog.InfoContext(ctx, "Request")
+
+	outerCtx, endOuter := StartSpan(ctx, "outer")
+	innerCtx, endInner := StartSpan(outerCtx, "inner")
+	slog.InfoContext(innerCtx, "Inside")
+	endInner()
+	endOuter()
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+
+	logs := make([]testSpanLog, len(lines))
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &logs[i]))
+		assert.Equal(t, "11111111111111111111111111111111", logs[i].Trace.Trace)
+		assert.Equal(t, int64(i+1), logs[i].Trace.Index)
+	}
+
+	request, inside, inner, outer := logs[0], logs[1], logs[2], logs[3]
+
+	assert.NotEmpty(t, request.Trace.Span)
+	assert.Empty(t, request.Trace.Parent)
+
+	assert.Equal(t, "Inside", inside.Msg)
+	assert.Equal(t, inner.Trace.Span, inside.Trace.Span)
+
+	assert.Equal(t, "Span", inner.Msg)
+	assert.Equal(t, "inner", inner.Span.Name)
+	assert.Equal(t, outer.Trace.Span, inner.Trace.Parent)
+
+	assert.Equal(t, "Span", outer.Msg)
+	assert.Equal(t, "outer", outer.Span.Name)
+	assert.Equal(t, request.Trace.Span, outer.Trace.Parent)
+	assert.NotEqual(t, outer.Trace.Span, request.Trace.Span)
+
+	assert.False(t, inner.Span.Start.Before(outer.Span.Start))
+	assert.GreaterOrEqual(t, outer.Span.Duration, inner.Span.Duration)
+}
+
+func TestStartSpanWithoutTrace(t *testing.T) {
+	ctx, end := StartSpan(t.Context(), "job")
+	defer end()
+
+	trace, ok := traceFromContext(ctx)
+	require.True(t, ok)
+	assert.Len(t, trace.trace, 32)
+	assert.Len(t, trace.span, 16)
+	assert.Empty(t, trace.parent)
+}
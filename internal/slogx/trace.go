@@ -7,13 +7,23 @@ import (
 	"sync/atomic"
 
 	"github.com/google/uuid"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type trace struct {
-	trace string
-	index *atomic.Int64
+	trace   string
+	request string
+	index   *atomic.Int64
+
+	// span and parent identify the active span started by [StartSpan]; span is the request's own
+	// span outside of any.
+	span   string
+	parent string
 }
 
+// TraceStarter starts a log trace per request. The trace ID is the one of the OpenTelemetry span in
+// the context, so that logs and traces can be joined. Without a span, the request ID is used if it
+// has the form of a trace ID, otherwise a new ID is made from a UUID.
 type TraceStarter struct {
 	newUUID func() (uuid.UUID, error)
 }
@@ -23,12 +33,34 @@ func NewTraceStarter(newUUID func() (uuid.UUID, error)) *TraceStarter {
 }
 
 func (s *TraceStarter) Start(ctx context.Context) (context.Context, error) {
-	traceID, err := s.newUUID()
-	if err != nil {
-		return nil, fmt.Errorf("new trace id: %w", err)
+	requestID, _ := RequestID(ctx)
+
+	spanContext := oteltrace.SpanContextFromContext(ctx)
+
+	spanID := spanContext.SpanID()
+	if !spanID.IsValid() {
+		spanID = newSpanID()
+	}
+
+	traceID := spanContext.TraceID()
+	if !traceID.IsValid() {
+		var ok bool
+		if traceID, ok = TraceIDFromRequestID(requestID); !ok {
+			id, err := s.newUUID()
+			if err != nil {
+				return nil, fmt.Errorf("new trace id: %w", err)
+			}
+
+			traceID = oteltrace.TraceID(id)
+		}
 	}
 
-	return withTrace(ctx, trace{trace: traceID.String(), index: &atomic.Int64{}}), nil
+	return withTrace(ctx, trace{
+		trace:   traceID.String(),
+		request: requestID,
+		index:   &atomic.Int64{},
+		span:    spanID.String(),
+	}), nil
 }
 
 var _ slog.Handler = (*traceHandler)(nil)
@@ -73,11 +105,30 @@ func (h *traceHandler) addAttrs(ctx context.Context, record slog.Record) slog.Re
 		return record
 	}
 
-	record.AddAttrs(slog.Group("trace", slog.String("trace", t.trace), slog.Int64("index", t.index.Add(1))))
+	attrs := []any{slog.String("trace", t.trace), slog.Int64("index", t.index.Add(1)), slog.String("span", t.span)}
+	if t.parent != "" {
+		attrs = append(attrs, slog.String("parent", t.parent))
+	}
+
+	if t.request != "" && t.request != t.trace {
+		attrs = append(attrs, slog.String("request", t.request))
+	}
+
+	record.AddAttrs(slog.Group("trace", attrs...))
 
 	return record
 }
 
+// TraceID returns the trace ID started by [TraceStarter] for the given context.
+func TraceID(ctx context.Context) (string, bool) {
+	t, ok := traceFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	return t.trace, true
+}
+
 type traceKeyType int8
 
 var traceKey traceKeyType = 1
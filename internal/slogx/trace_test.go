@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type testTrace struct {
@@ -50,7 +51,7 @@ func TestTrace(t *testing.T) {
 			Level: "INFO",
 			Msg:   "First message with context",
 			Trace: testTrace{
-				Trace: "11111111-1111-1111-1111-111111111111",
+				Trace: "11111111111111111111111111111111",
 				Index: 1,
 			},
 		},
@@ -58,7 +59,7 @@ func TestTrace(t *testing.T) {
 			Level: "INFO",
 			Msg:   "Second message with context",
 			Trace: testTrace{
-				Trace: "11111111-1111-1111-1111-111111111111",
+				Trace: "11111111111111111111111111111111",
 				Index: 2,
 			},
 		},
@@ -79,3 +80,21 @@ func TestTrace(t *testing.T) {
 		assert.Equal(t, wantLogs[i], gotLog)
 	}
 }
+
+func TestTraceFromSpan(t *testing.T) {
+	tracer := NewTraceStarter(func() (uuid.UUID, error) {
+		return uuid.MustParse("11111111-1111-1111-1111-111111111111"), nil
+	})
+
+	spanContext := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID: oteltrace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  oteltrace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})
+
+	ctx, err := tracer.Start(oteltrace.ContextWithSpanContext(t.Context(), spanContext))
+	require.NoError(t, err)
+
+	traceID, ok := TraceID(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+}
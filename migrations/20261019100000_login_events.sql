@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Table login_events keeps every authentication attempt, successful or not
+CREATE TABLE login_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NULL, -- NULL when the attempt could not be linked to a user
+    email VARCHAR(255),
+    method VARCHAR(20) NOT NULL, -- password, google, refresh, etc.
+    outcome VARCHAR(20) NOT NULL, -- success, failure
+    failure_reason VARCHAR(50),
+    ip VARCHAR(45),
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_events_user_id_created_at ON login_events (user_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE login_events;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Table one_time_codes keeps short-lived, single-use login and verification codes.
+-- Only keyed hashes of the code, link token and requesting device are stored.
+CREATE TABLE one_time_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    purpose VARCHAR(30) NOT NULL, -- email_login, etc.
+    destination VARCHAR(255) NOT NULL, -- email address or phone number
+    code_hash CHAR(64) NOT NULL,
+    link_token_hash CHAR(64) NOT NULL,
+    device_hash CHAR(64) NOT NULL,
+    ip VARCHAR(45),
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_one_time_codes_destination ON one_time_codes (purpose, destination, created_at DESC);
+CREATE INDEX idx_one_time_codes_ip ON one_time_codes (purpose, ip, created_at DESC);
+CREATE UNIQUE INDEX idx_one_time_codes_link_token_hash ON one_time_codes (link_token_hash);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE one_time_codes;
+-- +goose StatementEnd
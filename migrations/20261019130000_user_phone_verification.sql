@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE users ADD COLUMN phone_verified_at TIMESTAMPTZ NULL;
+
+-- A phone number can be verified by one user only
+CREATE UNIQUE INDEX idx_users_verified_phone ON users (phone) WHERE phone_verified_at IS NOT NULL AND deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_users_verified_phone;
+ALTER TABLE users DROP COLUMN phone_verified_at;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Email lookups are case-insensitive
+CREATE INDEX idx_users_email_lower ON users (LOWER(email));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_users_email_lower;
+-- +goose StatementEnd
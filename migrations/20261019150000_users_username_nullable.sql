@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Usernames are optional; NULLs do not collide in the unique index, empty strings do
+ALTER TABLE users ALTER COLUMN username DROP NOT NULL;
+UPDATE users SET username = NULL WHERE username = '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE users SET username = id::text WHERE username IS NULL;
+ALTER TABLE users ALTER COLUMN username SET NOT NULL;
+-- +goose StatementEnd
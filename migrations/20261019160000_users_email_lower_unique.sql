@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Addresses that differ only in case belong to the same mailbox. Lookups already resolved them to
+-- the oldest account, so the newer ones were unreachable; park them on an address nobody can own.
+UPDATE users SET email = id::text || '@duplicate.invalid'
+WHERE id IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (PARTITION BY LOWER(email) ORDER BY created_at, id) AS position
+        FROM users
+    ) ranked
+    WHERE position > 1
+);
+
+DROP INDEX idx_users_email_lower;
+CREATE UNIQUE INDEX idx_users_email_lower ON users (LOWER(email));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_users_email_lower;
+CREATE INDEX idx_users_email_lower ON users (LOWER(email));
+-- +goose StatementEnd